@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aureliano/db-unit-extractor/dataconv"
 	"github.com/aureliano/db-unit-extractor/reader"
@@ -24,12 +25,25 @@ type Conf struct {
 	FormattedOutput bool
 	OutputDir       string
 	References      map[string]interface{}
+	GenericReader   bool
+	Placeholder     string
+	NullToken       string
+	SQLDialect      string
+	Upsert          bool
+	GoPackage       string
+	Compression     string
+	NameTemplate    string
+	Split           bool
+	Merge           bool
 }
 
 type dbResponse struct {
-	table string
-	data  [][]*reader.DBColumn
-	err   error
+	index   int
+	table   string
+	summary writer.TableSummary
+	order   []string
+	data    [][]*reader.DBColumn
+	err     error
 }
 
 var (
@@ -50,38 +64,90 @@ func Extract(conf Conf, db reader.DBReader, writers []writer.FileWriter) error {
 		ds.MaxOpenConn = conf.MaxOpenConn
 		ds.MaxIdleConn = conf.MaxIdleConn
 
-		db, err = reader.NewReader(ds)
+		db, err = newReader(conf, ds)
 		if err != nil {
 			return err
 		}
 	}
 
 	if len(writers) == 0 {
-		writers = make([]writer.FileWriter, len(conf.OutputTypes))
+		writers, err = newWriters(conf, schema)
+		if err != nil {
+			return err
+		}
+	}
 
-		for i, outputTp := range conf.OutputTypes {
-			fname := filepath.Base(conf.SchemaPath)
-			fname = fname[:strings.LastIndex(fname, ".")]
+	for k, v := range conf.References {
+		schema.Refs[strings.ToLower(k)] = v
+	}
 
-			fc := writer.FileConf{
-				Type: outputTp, Formatted: conf.FormattedOutput, Directory: conf.OutputDir, Name: fname,
-			}
-			fw, e := writer.NewWriter(fc)
-			if e != nil {
-				return e
+	return extract(schema, db, writers, conf.Merge)
+}
+
+func newWriters(conf Conf, model schema.Model) ([]writer.FileWriter, error) {
+	writers := make([]writer.FileWriter, len(conf.OutputTypes))
+	order := tableOrder(model)
+	timestamp := time.Now()
+	dialect := conf.SQLDialect
+	if dialect == "" {
+		ds := reader.DataSource{DSN: conf.DSN}
+		dialect = ds.DriverName()
+	}
+
+	for i, outputTp := range conf.OutputTypes {
+		fname := filepath.Base(conf.SchemaPath)
+		fname = fname[:strings.LastIndex(fname, ".")]
+
+		fc := writer.FileConf{
+			Type: outputTp, Formatted: conf.FormattedOutput, Directory: conf.OutputDir, Name: fname,
+			NullToken: conf.NullToken, TableOrder: order, Dialect: dialect,
+			Upsert: conf.Upsert, Package: conf.GoPackage, Compression: conf.Compression,
+			NameTemplate: conf.NameTemplate, References: conf.References, Timestamp: timestamp, Split: conf.Split,
+		}
+		fw, err := writer.NewWriter(fc)
+		if err != nil {
+			return nil, err
+		}
+		writers[i] = fw
+	}
+
+	return writers, nil
+}
+
+func tableOrder(model schema.Model) []string {
+	groups := model.GroupedTables()
+	deepest := make(map[string]int)
+	for group, tables := range groups {
+		for _, table := range tables {
+			deepest[strings.ToLower(table.Name)] = group
+		}
+	}
+
+	order := make([]string, 0, len(deepest))
+	found := make(map[string]bool)
+
+	for group, tables := range groups {
+		for _, table := range tables {
+			name := strings.ToLower(table.Name)
+			if deepest[name] == group && !found[name] {
+				order = append(order, table.Name)
+				found[name] = true
 			}
-			writers[i] = fw
 		}
 	}
 
-	for k, v := range conf.References {
-		schema.Refs[strings.ToLower(k)] = v
+	return order
+}
+
+func newReader(conf Conf, ds reader.DBConnector) (reader.DBReader, error) {
+	if conf.GenericReader {
+		return reader.NewGenericReader(ds, conf.Placeholder)
 	}
 
-	return extract(schema, db, writers)
+	return reader.NewReader(ds)
 }
 
-func extract(model schema.Model, db reader.DBReader, writers []writer.FileWriter) error {
+func extract(model schema.Model, db reader.DBReader, writers []writer.FileWriter, merge bool) error {
 	cw := launchWriters(writers)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -90,7 +156,7 @@ func extract(model schema.Model, db reader.DBReader, writers []writer.FileWriter
 		db.StartDBProfiler(ctx)
 	}
 
-	if err := launchReaders(model, db, cw); err != nil {
+	if err := launchReaders(model, db, cw, merge); err != nil {
 		return err
 	}
 
@@ -113,48 +179,88 @@ func launchWriters(writers []writer.FileWriter) []chan dbResponse {
 	return chanWriters
 }
 
-func launchReaders(model schema.Model, db reader.DBReader, writers []chan dbResponse) error {
+func launchReaders(model schema.Model, db reader.DBReader, writers []chan dbResponse, merge bool) error {
 	converters := make([]dataconv.Converter, 0)
 	for _, id := range model.Converters {
 		converters = append(converters, dataconv.GetConverter(string(id)))
 	}
 
-	for _, tables := range model.GroupedTables() {
-		respChan := make(chan dbResponse)
-		tbSize := len(tables)
+	fetched := make(map[string]map[string]bool)
+	merged := make(map[string]*dbResponse)
+	for group, tables := range model.GroupedTables() {
+		responses, err := fetchGroup(model, group, tables, db, converters)
+		if err != nil {
+			return err
+		}
+
+		for _, res := range responses {
+			res.data = distinctRows(fetched, res)
+			updateReferences(model, res)
 
-		for _, table := range tables {
-			filters, err := resolveTableFilters(table, model.Refs)
-			if err != nil {
-				return fmt.Errorf("%w: %w", ErrExtractor, err)
+			if merge {
+				mergeResponse(merged, res)
+			} else {
+				sendResponse(writers, res)
 			}
+		}
+	}
 
-			go fetchData(respChan, table, db, converters, filters)
+	for _, table := range tableOrder(model) {
+		if res, exists := merged[strings.ToLower(table)]; exists {
+			res.table = table
+			writer.SortRows(res.data, res.order)
+			sendResponse(writers, *res)
 		}
+	}
 
-		counter := 0
-		for res := range respChan {
-			if res.err != nil {
-				return fmt.Errorf("%w: %w", ErrExtractor, res.err)
-			}
+	return nil
+}
 
-			updateReferences(model, res)
-			counter++
+func fetchGroup(model schema.Model, group int, tables []schema.Table, db reader.DBReader,
+	converters []dataconv.Converter) ([]dbResponse, error) {
+	respChan := make(chan dbResponse)
+	for i, table := range tables {
+		filters, err := resolveTableFilters(table, model.Refs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrExtractor, err)
+		}
 
-			if counter >= tbSize {
-				close(respChan)
-			}
+		summary := tableSummary(group+1, table, filters)
+		go fetchData(respChan, i, table, summary, db, converters, filters)
+	}
 
-			for _, w := range writers {
-				w <- res
-			}
+	responses := make([]dbResponse, len(tables))
+	for range tables {
+		res := <-respChan
+		if res.err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrExtractor, res.err)
 		}
+
+		responses[res.index] = res
 	}
 
-	return nil
+	return responses, nil
+}
+
+func mergeResponse(merged map[string]*dbResponse, response dbResponse) {
+	table := strings.ToLower(response.table)
+	res, exists := merged[table]
+	if !exists {
+		merged[table] = &response
+		return
+	}
+
+	res.data = append(res.data, response.data...)
+	res.summary.Filters = append(res.summary.Filters, response.summary.Filters...)
 }
 
-func fetchData(c chan dbResponse, table schema.Table,
+func sendResponse(writers []chan dbResponse, response dbResponse) {
+	for _, w := range writers {
+		w <- response
+	}
+}
+
+func fetchData(c chan dbResponse, index int, table schema.Table, summary writer.TableSummary,
 	db reader.DBReader, converters []dataconv.Converter, filters [][]interface{}) {
 	columns, err := db.FetchColumnsMetadata(table)
 	if err != nil {
@@ -162,16 +268,27 @@ func fetchData(c chan dbResponse, table schema.Table,
 		return
 	}
 
+	order, err := orderColumns(table, columns)
+	if err != nil {
+		c <- dbResponse{err: err}
+		return
+	}
+
 	data, err := db.FetchData(table.Name, columns, converters, filters)
 	if err != nil {
 		c <- dbResponse{err: err}
 		return
 	}
 
+	writer.SortRows(data, order)
+
 	c <- dbResponse{
-		table: table.Name,
-		data:  data,
-		err:   err,
+		index:   index,
+		table:   table.Name,
+		summary: summary,
+		order:   order,
+		data:    data,
+		err:     err,
 	}
 }
 
@@ -182,6 +299,12 @@ func writeData(c chan dbResponse, w writer.FileWriter) {
 
 	for res := range c {
 		if res.data != nil {
+			if sw, isSummaryWriter := w.(writer.SummaryWriter); isSummaryWriter {
+				if err := sw.WriteSummary(res.summary); err != nil {
+					shutdown(err)
+				}
+			}
+
 			if err := w.Write(res.table, res.data); err != nil {
 				shutdown(err)
 			}
@@ -189,6 +312,44 @@ func writeData(c chan dbResponse, w writer.FileWriter) {
 	}
 }
 
+func distinctRows(fetched map[string]map[string]bool, response dbResponse) [][]*reader.DBColumn {
+	table := strings.ToLower(response.table)
+	if fetched[table] == nil {
+		fetched[table] = make(map[string]bool)
+	}
+
+	rows := make([][]*reader.DBColumn, 0, len(response.data))
+	for _, row := range response.data {
+		key := rowKey(row)
+		if !fetched[table][key] {
+			fetched[table][key] = true
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+func rowKey(row []*reader.DBColumn) string {
+	columns := make([]*reader.DBColumn, 0, len(row))
+	for _, column := range row {
+		if column.PrimaryKey {
+			columns = append(columns, column)
+		}
+	}
+
+	if len(columns) == 0 {
+		columns = row
+	}
+
+	key := strings.Builder{}
+	for _, column := range columns {
+		key.WriteString(fmt.Sprintf("%s=%T:%v\x00", strings.ToLower(column.Name), column.Value, column.Value))
+	}
+
+	return key.String()
+}
+
 func updateReferences(model schema.Model, response dbResponse) {
 	for _, record := range response.data {
 		for _, column := range record {
@@ -242,6 +403,38 @@ func resolveTableFilters(table schema.Table, references map[string]interface{})
 	return filters, nil
 }
 
+func orderColumns(table schema.Table, columns []reader.DBColumn) ([]string, error) {
+	order := make([]string, len(table.OrderBy))
+	for i, name := range table.OrderBy {
+		found := false
+		for _, column := range columns {
+			if strings.EqualFold(column.Name, string(name)) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("order by column %s.%s not found", table.Name, name)
+		}
+
+		order[i] = string(name)
+	}
+
+	return order, nil
+}
+
+func tableSummary(group int, table schema.Table, filters [][]interface{}) writer.TableSummary {
+	summary := writer.TableSummary{Table: table.Name, Group: group, Filters: make([]writer.TableFilter, len(filters))}
+	for i, filter := range filters {
+		summary.Filters[i] = writer.TableFilter{
+			Name: table.Filters[i].Name, Expression: table.Filters[i].Value, Value: filter[1],
+		}
+	}
+
+	return summary
+}
+
 func shutdown(err error) {
 	fmt.Fprintf(os.Stdout, "%s: %s\n", ErrExtractor.Error(), err.Error())
 	os.Exit(1)
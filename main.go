@@ -6,7 +6,11 @@ import (
 	"runtime/pprof"
 
 	"github.com/aureliano/db-unit-extractor/cmd"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/microsoft/go-mssqldb"
 	_ "github.com/sijms/go-ora/v2"
+	_ "modernc.org/sqlite"
 )
 
 func main() {
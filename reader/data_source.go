@@ -3,6 +3,8 @@ package reader
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -23,6 +25,8 @@ type DBConnector interface {
 
 const MaxDBTimeout = time.Second * 30
 
+var sqlDriverNames = map[string]string{"sqlite3": "sqlite"}
+
 func NewDataSource() *DataSource {
 	return &DataSource{
 		MaxOpenConn: 1,
@@ -35,7 +39,7 @@ func (ds *DataSource) Connect(timeout time.Duration) (*sql.DB, error) {
 		return ds.DB, nil
 	}
 
-	db, err := sql.Open(ds.DriverName(), ds.ConnectionURL())
+	db, err := sql.Open(ds.sqlDriverName(), ds.ConnectionURL())
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +73,15 @@ func (ds *DataSource) DriverName() string {
 	return ds.DSN[:index]
 }
 
+func (ds *DataSource) sqlDriverName() string {
+	name := ds.DriverName()
+	if alias, exists := sqlDriverNames[name]; exists && isDriverRegistered(alias) {
+		return alias
+	}
+
+	return name
+}
+
 func (ds *DataSource) ConnectionURL() string {
 	if ds.DSN == "" {
 		return ""
@@ -79,5 +92,51 @@ func (ds *DataSource) ConnectionURL() string {
 		return ds.DSN[len(sqlite3Prefix):]
 	}
 
+	const mysqlPrefix = "mysql://"
+	if strings.HasPrefix(ds.DSN, mysqlPrefix) {
+		return mysqlConnectionURL(ds.DSN)
+	}
+
+	const sqlserverPrefix = "sqlserver://"
+	if strings.HasPrefix(ds.DSN, sqlserverPrefix) {
+		return sqlserverConnectionURL(ds.DSN)
+	}
+
 	return ds.DSN
 }
+
+func mysqlConnectionURL(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+
+	query := u.Query()
+	if !query.Has("parseTime") {
+		query.Set("parseTime", "true")
+	}
+
+	credentials := u.User.Username()
+	if password, defined := u.User.Password(); defined {
+		credentials = fmt.Sprintf("%s:%s", credentials, password)
+	}
+
+	return fmt.Sprintf("%s@tcp(%s)%s?%s", credentials, u.Host, u.Path, query.Encode())
+}
+
+func sqlserverConnectionURL(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.Path == "" {
+		return dsn
+	}
+
+	query := u.Query()
+	if !query.Has("database") {
+		query.Set("database", strings.TrimPrefix(u.Path, "/"))
+	}
+
+	u.Path = ""
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,195 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/dataconv"
+	"github.com/aureliano/db-unit-extractor/schema"
+)
+
+type GenericReader struct {
+	db          *sql.DB
+	profiling   *os.File
+	placeholder func(int) string
+}
+
+const (
+	PlaceholderQuestion = "?"
+	PlaceholderDollar   = "$n"
+	PlaceholderColon    = ":n"
+	PlaceholderAt       = "@pn"
+)
+
+var (
+	ErrUnsupportedPlaceholder = errors.New("unsupported placeholder style")
+	ErrAmbiguousTable         = errors.New("ambiguous table")
+)
+
+func NewGenericReader(ds DBConnector, placeholder string) (DBReader, error) {
+	if placeholder == "" {
+		placeholder = defaultPlaceholder(ds.DriverName())
+	}
+
+	ph, err := placeholderFunc(placeholder)
+	if err != nil {
+		return nil, err
+	}
+
+	db, pfile, err := connect(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return GenericReader{db: db, profiling: pfile, placeholder: ph}, nil
+}
+
+func PlaceholderStyles() []string {
+	return []string{PlaceholderQuestion, PlaceholderDollar, PlaceholderColon, PlaceholderAt}
+}
+
+func (r GenericReader) FetchColumnsMetadata(table schema.Table) ([]DBColumn, error) {
+	if err := r.assertUniqueTable(table); err != nil {
+		return nil, err
+	}
+
+	query := buildGenericSQLQueryColumnsMetadata(table)
+	return fetchColumnsMetadata(r.db, "Generic", table, query, isNullable)
+}
+
+func (r GenericReader) assertUniqueTable(table schema.Table) error {
+	owner, name := splitTableName(strings.ToUpper(table.Name))
+	if owner != "" {
+		return nil
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(DISTINCT TABLE_SCHEMA) FROM INFORMATION_SCHEMA.TABLES"+
+		" WHERE UPPER(TABLE_NAME) = '%s'", name)
+
+	var schemas int
+	if err := r.db.QueryRow(query).Scan(&schemas); err != nil {
+		log.Printf("Generic.FetchColumnsMetadata\nTable: %s\nQuery: %s\nError: %s\n", table.Name, query, err.Error())
+		return err
+	}
+
+	if schemas > 1 {
+		return fmt.Errorf("%w: %s found in %d schemas, qualify it as <schema>.%s",
+			ErrAmbiguousTable, table.Name, schemas, table.Name)
+	}
+
+	return nil
+}
+
+func (r GenericReader) FetchData(table string, fields []DBColumn, converters []dataconv.Converter,
+	filters [][]interface{}) ([][]*DBColumn, error) {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.Name
+	}
+	query := buildSQLQuery(table, columns, filters, r.placeholder)
+
+	arrValues, err := bindFilters(table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchData(r.db, fields, converters, genericValue, arrValues, query)
+}
+
+func (r GenericReader) ProfilerMode() bool {
+	return r.profiling != nil
+}
+
+func (r GenericReader) StartDBProfiler(ctx context.Context) {
+	startDBProfiler(ctx, r.db, r.profiling)
+}
+
+func isDriverRegistered(driverName string) bool {
+	for _, name := range sql.Drivers() {
+		if strings.EqualFold(name, driverName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func defaultPlaceholder(driverName string) string {
+	switch strings.ToLower(driverName) {
+	case "postgres", "pgx", "cockroach":
+		return PlaceholderDollar
+	case "oracle", "godror", "oci8":
+		return PlaceholderColon
+	case "sqlserver", "mssql", "azuresql":
+		return PlaceholderAt
+	default:
+		return PlaceholderQuestion
+	}
+}
+
+func placeholderFunc(style string) (func(int) string, error) {
+	switch style {
+	case PlaceholderQuestion:
+		return func(int) string { return "?" }, nil
+	case PlaceholderDollar:
+		return func(i int) string { return fmt.Sprintf("$%d", i) }, nil
+	case PlaceholderColon:
+		return func(i int) string { return fmt.Sprintf(":%d", i) }, nil
+	case PlaceholderAt:
+		return func(i int) string { return fmt.Sprintf("@p%d", i) }, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedPlaceholder, style)
+	}
+}
+
+func buildGenericSQLQueryColumnsMetadata(table schema.Table) string {
+	var builder strings.Builder
+	builder.WriteString("SELECT COLUMN_NAME, UPPER(DATA_TYPE), IS_NULLABLE, CHARACTER_MAXIMUM_LENGTH,")
+	builder.WriteString(" NUMERIC_PRECISION, NUMERIC_SCALE, ")
+	builder.WriteString(informationSchemaPrimaryKeyColumn)
+	builder.WriteString(" FROM INFORMATION_SCHEMA.COLUMNS WHERE")
+
+	owner, name := splitTableName(strings.ToUpper(table.Name))
+	builder.WriteString(fmt.Sprintf(" UPPER(TABLE_NAME) = '%s'", name))
+	if owner != "" {
+		builder.WriteString(fmt.Sprintf(" AND UPPER(TABLE_SCHEMA) = '%s'", owner))
+	}
+
+	if len(table.Columns) > 0 || len(table.Ignore) > 0 {
+		builder.WriteString(" AND UPPER(COLUMN_NAME)")
+
+		if len(table.Ignore) > 0 {
+			builder.WriteString(" NOT")
+		}
+
+		builder.WriteString(fmt.Sprintf(" IN(%s)", strings.ToUpper(table.FormattedSelectColumns())))
+	}
+
+	builder.WriteString(" ORDER BY ORDINAL_POSITION")
+
+	return builder.String()
+}
+
+func genericValue(column DBColumn, value interface{}) (interface{}, error) {
+	bts, isBytes := value.([]byte)
+	if !isBytes || isBinaryType(column.Type) {
+		return value, nil
+	}
+
+	return string(bts), nil
+}
+
+func isBinaryType(dtype string) bool {
+	for _, tp := range []string{"BINARY", "BLOB", "BYTEA", "RAW", "IMAGE"} {
+		if strings.Contains(dtype, tp) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,167 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/dataconv"
+	"github.com/aureliano/db-unit-extractor/schema"
+)
+
+type MySQLReader struct {
+	db        *sql.DB
+	profiling *os.File
+}
+
+const (
+	mysqlDateLayout     = "2006-01-02"
+	mysqlDateTimeLayout = "2006-01-02 15:04:05.999999"
+)
+
+func init() {
+	RegisterReader("mysql", newMySQL)
+}
+
+func (r MySQLReader) FetchColumnsMetadata(table schema.Table) ([]DBColumn, error) {
+	query := buildMySQLSQLQueryColumnsMetadata(table)
+	return fetchColumnsMetadata(r.db, "MySQL", table, query, isNullable)
+}
+
+func (r MySQLReader) FetchData(table string, fields []DBColumn, converters []dataconv.Converter,
+	filters [][]interface{}) ([][]*DBColumn, error) {
+	query := buildMySQLSQLQueryColumns(table, fields, filters)
+
+	arrValues, err := bindFilters(table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchData(r.db, fields, converters, mysqlValue, arrValues, query)
+}
+
+func (r MySQLReader) ProfilerMode() bool {
+	return r.profiling != nil
+}
+
+func (r MySQLReader) StartDBProfiler(ctx context.Context) {
+	startDBProfiler(ctx, r.db, r.profiling)
+}
+
+func newMySQL(ds DBConnector) (DBReader, error) {
+	db, pfile, err := connect(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return MySQLReader{db: db, profiling: pfile}, nil
+}
+
+func buildMySQLSQLQueryColumnsMetadata(table schema.Table) string {
+	var builder strings.Builder
+	builder.WriteString("SELECT COLUMN_NAME,")
+	builder.WriteString(" CASE WHEN COLUMN_TYPE = 'tinyint(1)' THEN 'BOOLEAN' ELSE UPPER(DATA_TYPE) END,")
+	builder.WriteString(" IS_NULLABLE, CHARACTER_MAXIMUM_LENGTH, NUMERIC_PRECISION, NUMERIC_SCALE,")
+	builder.WriteString(" CASE WHEN COLUMN_KEY = 'PRI' THEN 'YES' ELSE 'NO' END")
+	builder.WriteString(" FROM information_schema.COLUMNS WHERE")
+	owner, name := splitTableName(table.Name)
+	if owner == "" {
+		builder.WriteString(" TABLE_SCHEMA = DATABASE()")
+	} else {
+		builder.WriteString(fmt.Sprintf(" TABLE_SCHEMA = '%s'", owner))
+	}
+
+	builder.WriteString(fmt.Sprintf(" AND TABLE_NAME = '%s'", name))
+	builder.WriteString(" AND EXTRA NOT LIKE '%GENERATED%'")
+
+	if len(table.Columns) > 0 || len(table.Ignore) > 0 {
+		builder.WriteString(" AND COLUMN_NAME")
+
+		if len(table.Ignore) > 0 {
+			builder.WriteString(" NOT")
+		}
+
+		builder.WriteString(fmt.Sprintf(" IN(%s)", table.FormattedSelectColumns()))
+	}
+
+	builder.WriteString(" ORDER BY ORDINAL_POSITION")
+
+	return builder.String()
+}
+
+func buildMySQLSQLQueryColumns(table string, fields []DBColumn, filters [][]interface{}) string {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = quoteMySQLIdentifier(field.Name)
+	}
+
+	return buildSQLQuery(quoteMySQLIdentifier(table), columns, quoteFilters(filters, quoteMySQLIdentifier),
+		func(int) string { return "?" })
+}
+
+func quoteMySQLIdentifier(name string) string {
+	return quoteIdentifier(name, "`", "`")
+}
+
+func mysqlValue(column DBColumn, value interface{}) (interface{}, error) {
+	switch column.Type {
+	case "BOOLEAN":
+		return mysqlBoolean(value), nil
+	case "BIT":
+		if bts, isBytes := value.([]byte); isBytes {
+			return mysqlBit(bts), nil
+		}
+	case "DATE", "DATETIME", "TIMESTAMP":
+		if bts, isBytes := value.([]byte); isBytes {
+			return mysqlTime(string(bts))
+		}
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB":
+		return value, nil
+	}
+
+	if bts, isBytes := value.([]byte); isBytes {
+		return string(bts), nil
+	}
+
+	return value, nil
+}
+
+func mysqlBoolean(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		return v != 0
+	case []byte:
+		return string(v) != "0"
+	default:
+		return value
+	}
+}
+
+func mysqlBit(bts []byte) uint64 {
+	const size = 8
+	buf := make([]byte, size)
+
+	if len(bts) > size {
+		bts = bts[len(bts)-size:]
+	}
+	copy(buf[size-len(bts):], bts)
+
+	return binary.BigEndian.Uint64(buf)
+}
+
+func mysqlTime(value string) (interface{}, error) {
+	if strings.HasPrefix(value, "0000-00-00") {
+		return time.Time{}, nil
+	}
+
+	layout := mysqlDateTimeLayout
+	if len(value) == len(mysqlDateLayout) {
+		layout = mysqlDateLayout
+	}
+
+	return time.ParseInLocation(layout, value, time.UTC)
+}
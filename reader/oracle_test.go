@@ -37,11 +37,12 @@ func TestFetchColumnsMetadata(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE",
+		"PRIMARY_KEY",
 	}).
-		AddRow("ID", "NUMBER", "F", 22, 2, 0).
-		AddRow("USER_ID", "NUMBER", "F", 22, 2, 0).
-		AddRow("STATUS", "VARCHAR2", "Y", 15, nil, nil).
-		AddRow("TOTAL", "NUMBER", "F", 22, 17, 2)
+		AddRow("ID", "NUMBER", "F", 22, 2, 0, "Y").
+		AddRow("USER_ID", "NUMBER", "F", 22, 2, 0, "N").
+		AddRow("STATUS", "VARCHAR2", "Y", 15, nil, nil, "N").
+		AddRow("TOTAL", "NUMBER", "F", 22, 17, 2, "N")
 
 	mock.ExpectQuery("^SELECT (.+) FROM  ALL_TAB_COLS").WillReturnRows(rows)
 
@@ -55,6 +56,7 @@ func TestFetchColumnsMetadata(t *testing.T) {
 	assert.Equal(t, "ID", columns[0].Name)
 	assert.Equal(t, "NUMBER", columns[0].Type)
 	assert.Equal(t, false, columns[0].Nullable)
+	assert.Equal(t, true, columns[0].PrimaryKey)
 	assert.EqualValues(t, 22, columns[0].Length)
 	assert.EqualValues(t, 2, columns[0].DecimalSize.Precision)
 	assert.EqualValues(t, 0, columns[0].DecimalSize.Scale)
@@ -62,6 +64,7 @@ func TestFetchColumnsMetadata(t *testing.T) {
 	assert.Equal(t, "USER_ID", columns[1].Name)
 	assert.Equal(t, "NUMBER", columns[1].Type)
 	assert.Equal(t, false, columns[1].Nullable)
+	assert.Equal(t, false, columns[1].PrimaryKey)
 	assert.EqualValues(t, 22, columns[1].Length)
 	assert.EqualValues(t, 2, columns[1].DecimalSize.Precision)
 	assert.EqualValues(t, 0, columns[1].DecimalSize.Scale)
@@ -91,9 +94,10 @@ func TestFetchColumnsMetadataIgnore(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE",
+		"PRIMARY_KEY",
 	}).
-		AddRow("ID", "NUMBER", "F", 22, 2, 0).
-		AddRow("TOTAL", "NUMBER", "F", 22, 17, 2)
+		AddRow("ID", "NUMBER", "F", 22, 2, 0, "Y").
+		AddRow("TOTAL", "NUMBER", "F", 22, 17, 2, "N")
 
 	mock.ExpectQuery("^SELECT (.+) FROM  ALL_TAB_COLS").WillReturnRows(rows)
 
@@ -129,11 +133,12 @@ func TestFetchColumnsMetadataAllColumns(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE",
+		"PRIMARY_KEY",
 	}).
-		AddRow("ID", "NUMBER", "F", 22, 2, 0).
-		AddRow("USER_ID", "NUMBER", "F", 22, 2, 0).
-		AddRow("STATUS", "VARCHAR2", "Y", 15, nil, nil).
-		AddRow("TOTAL", "NUMBER", "F", 22, 17, 2)
+		AddRow("ID", "NUMBER", "F", 22, 2, 0, "Y").
+		AddRow("USER_ID", "NUMBER", "F", 22, 2, 0, "N").
+		AddRow("STATUS", "VARCHAR2", "Y", 15, nil, nil, "N").
+		AddRow("TOTAL", "NUMBER", "F", 22, 17, 2, "N")
 
 	mock.ExpectQuery("^SELECT (.+) FROM  ALL_TAB_COLS").WillReturnRows(rows)
 
@@ -195,7 +200,8 @@ func TestFetchColumnsMetadataScanRowError(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE",
-	}).AddRow("TOTAL", "NUMBER", "F", "22r", 17, 2)
+		"PRIMARY_KEY",
+	}).AddRow("TOTAL", "NUMBER", "F", "22r", 17, 2, "N")
 
 	mock.ExpectQuery("^SELECT (.+) FROM  ALL_TAB_COLS").WillReturnRows(rows)
 
@@ -214,7 +220,8 @@ func TestFetchColumnsMetadataRowsError(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE",
-	}).AddRow("TOTAL", "NUMBER", "F", 22, 17, 2).RowError(0, sqlErr)
+		"PRIMARY_KEY",
+	}).AddRow("TOTAL", "NUMBER", "F", 22, 17, 2, "N").RowError(0, sqlErr)
 
 	mock.ExpectQuery("^SELECT (.+) FROM  ALL_TAB_COLS").WillReturnRows(rows)
 
@@ -232,6 +239,7 @@ func TestFetchColumnsMetadataEmptyResultError(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{
 		"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_LENGTH", "DATA_PRECISION", "DATA_SCALE",
+		"PRIMARY_KEY",
 	})
 
 	mock.ExpectQuery("^SELECT (.+) FROM  ALL_TAB_COLS").WillReturnRows(rows)
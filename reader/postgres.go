@@ -0,0 +1,104 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/dataconv"
+	"github.com/aureliano/db-unit-extractor/schema"
+)
+
+type PostgresReader struct {
+	db        *sql.DB
+	profiling *os.File
+}
+
+func init() {
+	RegisterReader("postgres", newPostgres)
+}
+
+func (r PostgresReader) FetchColumnsMetadata(table schema.Table) ([]DBColumn, error) {
+	query := buildPostgresSQLQueryColumnsMetadata(table)
+	return fetchColumnsMetadata(r.db, "Postgres", table, query, isNullable)
+}
+
+func (r PostgresReader) FetchData(table string, fields []DBColumn, converters []dataconv.Converter,
+	filters [][]interface{}) ([][]*DBColumn, error) {
+	query := buildPostgresSQLQueryColumns(table, fields, filters)
+
+	arrValues, err := bindFilters(table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchData(r.db, fields, converters, postgresValue, arrValues, query)
+}
+
+func (r PostgresReader) ProfilerMode() bool {
+	return r.profiling != nil
+}
+
+func (r PostgresReader) StartDBProfiler(ctx context.Context) {
+	startDBProfiler(ctx, r.db, r.profiling)
+}
+
+func newPostgres(ds DBConnector) (DBReader, error) {
+	db, pfile, err := connect(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return PostgresReader{db: db, profiling: pfile}, nil
+}
+
+func buildPostgresSQLQueryColumnsMetadata(table schema.Table) string {
+	var builder strings.Builder
+	builder.WriteString("SELECT column_name, UPPER(udt_name), is_nullable, character_maximum_length,")
+	builder.WriteString(" numeric_precision, numeric_scale, ")
+	builder.WriteString(informationSchemaPrimaryKeyColumn)
+	builder.WriteString(" FROM information_schema.columns WHERE")
+	owner, name := splitTableName(strings.ToLower(table.Name))
+	if owner == "" {
+		builder.WriteString(" table_schema = current_schema()")
+	} else {
+		builder.WriteString(fmt.Sprintf(" table_schema = '%s'", owner))
+	}
+
+	builder.WriteString(fmt.Sprintf(" AND table_name = '%s'", name))
+	builder.WriteString(" AND is_generated = 'NEVER'")
+
+	if len(table.Columns) > 0 || len(table.Ignore) > 0 {
+		builder.WriteString(" AND column_name")
+
+		if len(table.Ignore) > 0 {
+			builder.WriteString(" NOT")
+		}
+
+		builder.WriteString(fmt.Sprintf(" IN(%s)", strings.ToLower(table.FormattedSelectColumns())))
+	}
+
+	builder.WriteString(" ORDER BY ordinal_position")
+
+	return builder.String()
+}
+
+func buildPostgresSQLQueryColumns(table string, fields []DBColumn, filters [][]interface{}) string {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.Name
+	}
+
+	return buildSQLQuery(table, columns, filters, func(i int) string { return fmt.Sprintf("$%d", i) })
+}
+
+func postgresValue(column DBColumn, value interface{}) (interface{}, error) {
+	bts, isBytes := value.([]byte)
+	if !isBytes || column.Type == "BYTEA" {
+		return value, nil
+	}
+
+	return string(bts), nil
+}
@@ -2,10 +2,14 @@ package reader
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"log"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aureliano/db-unit-extractor/dataconv"
@@ -21,6 +25,7 @@ type DBColumn struct {
 	Name        string
 	Type        string
 	Nullable    bool
+	PrimaryKey  bool
 	Length      int64
 	DecimalSize DecimalColumn
 	Value       interface{}
@@ -30,6 +35,12 @@ var ErrUnsupportedDBReader = errors.New("unsupported database")
 
 const DBSnapshotDealy = time.Millisecond * 200
 
+const informationSchemaPrimaryKeyColumn = "CASE WHEN EXISTS (SELECT 1 FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS TC" +
+	" JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE KCU ON KCU.CONSTRAINT_SCHEMA = TC.CONSTRAINT_SCHEMA" +
+	" AND KCU.CONSTRAINT_NAME = TC.CONSTRAINT_NAME WHERE TC.CONSTRAINT_TYPE = 'PRIMARY KEY'" +
+	" AND KCU.TABLE_SCHEMA = COLUMNS.TABLE_SCHEMA AND KCU.TABLE_NAME = COLUMNS.TABLE_NAME" +
+	" AND KCU.COLUMN_NAME = COLUMNS.COLUMN_NAME) THEN 'YES' ELSE 'NO' END"
+
 type DBReader interface {
 	FetchColumnsMetadata(schema.Table) ([]DBColumn, error)
 	FetchData(string, []DBColumn, []dataconv.Converter, [][]interface{}) ([][]*DBColumn, error)
@@ -37,28 +48,364 @@ type DBReader interface {
 	StartDBProfiler(context.Context)
 }
 
+type ReaderFactory func(DBConnector) (DBReader, error)
+
+type valueMapper func(DBColumn, interface{}) (interface{}, error)
+
+var (
+	readerFactories = make(map[string]ReaderFactory)
+	readersMutex    sync.RWMutex
+)
+
+func RegisterReader(driverName string, factory ReaderFactory) {
+	readersMutex.Lock()
+	defer readersMutex.Unlock()
+
+	readerFactories[strings.ToLower(driverName)] = factory
+}
+
+func ReaderExists(driverName string) bool {
+	return readerFactory(driverName) != nil
+}
+
+func readerFactory(driverName string) ReaderFactory {
+	readersMutex.RLock()
+	defer readersMutex.RUnlock()
+
+	return readerFactories[strings.ToLower(driverName)]
+}
+
+func DriverSupported(driverName string) bool {
+	return ReaderExists(driverName) || isDriverRegistered(driverName)
+}
+
+func RegisteredDrivers() []string {
+	readersMutex.RLock()
+	drivers := make([]string, 0, len(readerFactories))
+	for driverName := range readerFactories {
+		drivers = append(drivers, driverName)
+	}
+	readersMutex.RUnlock()
+	sort.Strings(drivers)
+
+	return drivers
+}
+
 func NewReader(ds DBConnector) (DBReader, error) {
-	if strings.EqualFold(ds.DriverName(), "oracle") {
-		return newOracle(ds)
+	if factory := readerFactory(ds.DriverName()); factory != nil {
+		return factory(ds)
+	}
+
+	if isDriverRegistered(ds.DriverName()) {
+		return NewGenericReader(ds, "")
 	}
 
 	return nil, fmt.Errorf("%w: %s", ErrUnsupportedDBReader, ds.DriverName())
 }
 
-func newOracle(ds DBConnector) (DBReader, error) {
+func connect(ds DBConnector) (*sql.DB, *os.File, error) {
 	db, err := ds.Connect(MaxDBTimeout)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	dbProfile := os.Getenv("DB_PROFILE")
 	var pfile *os.File
 	if dbProfile != "" {
 		pfile, err = os.Create(dbProfile)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return db, pfile, nil
+}
+
+func fetchColumnsMetadata(db *sql.DB, vendor string, table schema.Table, query string,
+	flag func(string) bool) ([]DBColumn, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		log.Printf("%s.FetchColumnsMetadata\nTable: %s\nQuery: %s\nError: %s\n", vendor, table.Name, query, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]DBColumn, 0, len(table.SelectColumns()))
+
+	for rows.Next() {
+		rec := DBColumn{}
+		var isNullable, isPrimaryKey string
+		var length, precision, scale sql.NullInt64
+
+		err = rows.Scan(&rec.Name, &rec.Type, &isNullable, &length, &precision, &scale, &isPrimaryKey)
+		if err != nil {
+			log.Printf("%s.FetchColumnsMetadata\nTable: %s\nScan error: %s\n", vendor, table.Name, err.Error())
+			return nil, err
+		}
+
+		rec.Length = length.Int64
+		rec.DecimalSize.Precision = precision.Int64
+		rec.DecimalSize.Scale = scale.Int64
+
+		rec.Nullable = flag(isNullable)
+		rec.PrimaryKey = flag(isPrimaryKey)
+		records = append(records, rec)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("%s.FetchColumnsMetadata\nTable: %s\nFetch rows error: %s\n", vendor, table.Name, err.Error())
+		return nil, err
+	} else if len(records) == 0 {
+		return nil, fmt.Errorf(
+			"no metadata found for table %s (make sure it exists and user has proper grants)", table.Name)
+	}
+
+	return records, nil
+}
+
+func bindFilters(table string, filters [][]interface{}) ([][]interface{}, error) {
+	size := len(filters)
+	ind := make([]int, 0)
+	values := make([]interface{}, size)
+	expectedArrValuesSize := 0
+
+	for i := 0; i < size; i++ {
+		value, multivalued := filters[i][1].([]interface{})
+		if multivalued {
+			ind = append(ind, i)
+			expectedArrValuesSize += len(value)
+		} else {
+			values[i] = filters[i][1]
+		}
+	}
+
+	arrValues := make([][]interface{}, 0)
+	if len(ind) == 0 && !emptyFilter(values) {
+		arrValues = append(arrValues, values)
+		expectedArrValuesSize++
+	}
+
+	for _, i := range ind {
+		for _, v := range filters[i][1].([]interface{}) {
+			cpValues := make([]interface{}, len(values))
+			copy(cpValues, values)
+			cpValues[i] = v
+
+			if !emptyFilter(cpValues) {
+				arrValues = append(arrValues, cpValues)
+			}
+		}
+	}
+
+	wrongValuesSize := len(arrValues) != expectedArrValuesSize
+	filterWithoutValues := len(arrValues) == 0 && len(filters) > 0
+	if wrongValuesSize || filterWithoutValues {
+		return nil, fmt.Errorf("not all filters were bound for table %s `%v'", table, filters)
+	}
+
+	return arrValues, nil
+}
+
+func buildSQLQuery(table string, columns []string, filters [][]interface{}, placeholder func(int) string) string {
+	var sql strings.Builder
+
+	sql.WriteString("SELECT ")
+	sql.WriteString(strings.Join(columns, ","))
+	sql.WriteString(fmt.Sprintf(" FROM %s", table))
+
+	size := len(filters)
+	if size > 0 {
+		sql.WriteString(" WHERE ")
+	}
+
+	for i := 0; i < size; i++ {
+		sql.WriteString(fmt.Sprintf("%s = %s", filters[i][0], placeholder(i+1)))
+		if i < size-1 {
+			sql.WriteString(" AND ")
+		}
+	}
+
+	return sql.String()
+}
+
+func splitTableName(name string) (string, string) {
+	index := strings.LastIndex(name, ".")
+	if index < 0 {
+		return "", name
+	}
+
+	return name[:index], name[index+1:]
+}
+
+func quoteIdentifier(name, left, right string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = fmt.Sprintf("%s%s%s", left, strings.ReplaceAll(part, right, right+right), right)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func quoteFilters(filters [][]interface{}, quote func(string) string) [][]interface{} {
+	quotedFilters := make([][]interface{}, len(filters))
+	for i, filter := range filters {
+		quotedFilters[i] = []interface{}{quote(fmt.Sprint(filter[0])), filter[1]}
+	}
+
+	return quotedFilters
+}
+
+func fetchData(db *sql.DB, fields []DBColumn, converters []dataconv.Converter, mapper valueMapper,
+	arrValues [][]interface{}, query string) ([][]*DBColumn, error) {
+	rows := make([][]*DBColumn, 0, len(arrValues))
+
+	if len(arrValues) > 0 {
+		for _, filterValues := range arrValues {
+			data, err := executeQuery(db, fields, converters, mapper, filterValues, query)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, data...)
+		}
+	} else {
+		data, err := executeQuery(db, fields, converters, mapper, []interface{}{}, query)
 		if err != nil {
 			return nil, err
 		}
+		rows = append(rows, data...)
 	}
 
-	return OracleReader{db: db, profiling: pfile}, err
+	return rows, nil
+}
+
+func executeQuery(db *sql.DB, fields []DBColumn, converters []dataconv.Converter, mapper valueMapper,
+	filters []interface{}, query string) ([][]*DBColumn, error) {
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		log.Printf("Reader.executeQuery\nQuery: %s\nPrepare statement error: %s\n", query, err.Error())
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(filters...)
+	if err != nil {
+		log.Printf("Reader.executeQuery\nQuery: %s\nQuery error: %s\n", query, err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	return readDataSet(fields, rows, converters, mapper)
+}
+
+func readDataSet(fields []DBColumn, rows *sql.Rows, converters []dataconv.Converter,
+	mapper valueMapper) ([][]*DBColumn, error) {
+	columns, _ := rows.Columns()
+	count := len(columns)
+	values := make([]interface{}, count)
+	valuePtrs := make([]interface{}, count)
+
+	data := make([][]*DBColumn, 0)
+
+	for rows.Next() {
+		for i := range columns {
+			valuePtrs[i] = &values[i]
+		}
+
+		_ = rows.Scan(valuePtrs...)
+
+		row := make([]*DBColumn, count)
+		for i, field := range fields {
+			f := field
+			row[i] = &f
+		}
+
+		for i := range columns {
+			value, err := fetchValue(*row[i], values[i], converters, mapper)
+			if err != nil {
+				log.Printf("Reader.readDataSet\nFetch value error: %s\n", err.Error())
+				log.Printf("Field: %s - Value: %v\n", columns[i], values[i])
+				return nil, err
+			}
+
+			row[i].Value = value
+		}
+
+		data = append(data, row)
+	}
+
+	return data, rows.Err()
+}
+
+func fetchValue(column DBColumn, value interface{}, converters []dataconv.Converter,
+	mapper valueMapper) (interface{}, error) {
+	if mapper != nil && value != nil {
+		var err error
+		if value, err = mapper(column, value); err != nil {
+			return nil, err
+		}
+	}
+
+	converter := findConverter(value, converters)
+	if converter != nil {
+		return converter.Convert(column.Type, value)
+	}
+
+	return value, nil
+}
+
+func findConverter(value interface{}, converters []dataconv.Converter) dataconv.Converter {
+	for _, converter := range converters {
+		if converter.Handle(value) {
+			return converter
+		}
+	}
+
+	return nil
+}
+
+func emptyFilter(filter []interface{}) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, f := range filter {
+		if f == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func startDBProfiler(ctx context.Context, db *sql.DB, profiling *os.File) {
+	go func(c context.Context) {
+		for {
+			select {
+			case <-c.Done():
+				_ = profiling.Close()
+				return
+			default:
+				profileSnapshot(db, profiling)
+				time.Sleep(DBSnapshotDealy)
+			}
+		}
+	}(ctx)
+}
+
+func profileSnapshot(db *sql.DB, profiling *os.File) {
+	if profiling == nil {
+		return
+	}
+
+	stats := db.Stats()
+	record := fmt.Sprintf("%d %d %d %d %d %d %d %d %d\n", stats.MaxOpenConnections, stats.OpenConnections,
+		stats.InUse, stats.Idle, stats.WaitCount, stats.WaitDuration, stats.MaxIdleClosed, stats.MaxIdleTimeClosed,
+		stats.MaxLifetimeClosed)
+
+	_, _ = profiling.WriteString(record)
+}
+
+func isNullable(str string) bool {
+	return str == "YES"
 }
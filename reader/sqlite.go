@@ -0,0 +1,162 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/dataconv"
+	"github.com/aureliano/db-unit-extractor/schema"
+)
+
+type SQLiteReader struct {
+	db        *sql.DB
+	profiling *os.File
+}
+
+const (
+	sqliteIntegerAffinity = "INTEGER"
+	sqliteTextAffinity    = "TEXT"
+	sqliteBlobAffinity    = "BLOB"
+	sqliteRealAffinity    = "REAL"
+	sqliteNumericAffinity = "NUMERIC"
+)
+
+var sqliteTypeRegExp = regexp.MustCompile(`^([^(]*?)\s*(\(\s*(\d+)\s*(,\s*(\d+)\s*)?\))?$`)
+
+func init() {
+	RegisterReader("sqlite3", newSQLite)
+}
+
+func (r SQLiteReader) FetchColumnsMetadata(table schema.Table) ([]DBColumn, error) {
+	query := buildSQLiteSQLQueryColumnsMetadata(table)
+	records, err := fetchColumnsMetadata(r.db, "SQLite", table, query, isNullable)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		sqliteColumnType(&records[i])
+	}
+
+	return records, nil
+}
+
+func (r SQLiteReader) FetchData(table string, fields []DBColumn, converters []dataconv.Converter,
+	filters [][]interface{}) ([][]*DBColumn, error) {
+	query := buildSQLiteSQLQueryColumns(table, fields, filters)
+
+	arrValues, err := bindFilters(table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchData(r.db, fields, converters, sqliteValue, arrValues, query)
+}
+
+func (r SQLiteReader) ProfilerMode() bool {
+	return r.profiling != nil
+}
+
+func (r SQLiteReader) StartDBProfiler(ctx context.Context) {
+	startDBProfiler(ctx, r.db, r.profiling)
+}
+
+func newSQLite(ds DBConnector) (DBReader, error) {
+	db, pfile, err := connect(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLiteReader{db: db, profiling: pfile}, nil
+}
+
+func buildSQLiteSQLQueryColumnsMetadata(table schema.Table) string {
+	var builder strings.Builder
+	builder.WriteString("SELECT name, UPPER(type), CASE WHEN \"notnull\" = 0 AND pk = 0 THEN 'YES' ELSE 'NO' END,")
+	builder.WriteString(" NULL, NULL, NULL, CASE WHEN pk > 0 THEN 'YES' ELSE 'NO' END")
+	owner, name := splitTableName(table.Name)
+	if owner == "" {
+		builder.WriteString(fmt.Sprintf(" FROM pragma_table_info('%s')", name))
+	} else {
+		builder.WriteString(fmt.Sprintf(" FROM pragma_table_info('%s', '%s')", name, owner))
+	}
+
+	if len(table.Columns) > 0 || len(table.Ignore) > 0 {
+		builder.WriteString(" WHERE LOWER(name)")
+
+		if len(table.Ignore) > 0 {
+			builder.WriteString(" NOT")
+		}
+
+		builder.WriteString(fmt.Sprintf(" IN(%s)", strings.ToLower(table.FormattedSelectColumns())))
+	}
+
+	builder.WriteString(" ORDER BY cid")
+
+	return builder.String()
+}
+
+func buildSQLiteSQLQueryColumns(table string, fields []DBColumn, filters [][]interface{}) string {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = quoteSQLiteIdentifier(field.Name)
+	}
+
+	return buildSQLQuery(quoteSQLiteIdentifier(table), columns, quoteFilters(filters, quoteSQLiteIdentifier),
+		func(int) string { return "?" })
+}
+
+func quoteSQLiteIdentifier(name string) string {
+	return quoteIdentifier(name, "\"", "\"")
+}
+
+func sqliteColumnType(column *DBColumn) {
+	matches := sqliteTypeRegExp.FindStringSubmatch(column.Type)
+	if matches == nil {
+		return
+	}
+
+	column.Type = matches[1]
+	if column.Type == "" {
+		column.Type = sqliteBlobAffinity
+	}
+
+	size, _ := strconv.ParseInt(matches[3], 10, 64)
+	scale, _ := strconv.ParseInt(matches[5], 10, 64)
+
+	if sqliteAffinity(column.Type) == sqliteTextAffinity {
+		column.Length = size
+	} else {
+		column.DecimalSize.Precision = size
+		column.DecimalSize.Scale = scale
+	}
+}
+
+func sqliteAffinity(dtype string) string {
+	switch {
+	case strings.Contains(dtype, "INT"):
+		return sqliteIntegerAffinity
+	case strings.Contains(dtype, "CHAR"), strings.Contains(dtype, "CLOB"), strings.Contains(dtype, "TEXT"):
+		return sqliteTextAffinity
+	case dtype == "", strings.Contains(dtype, "BLOB"):
+		return sqliteBlobAffinity
+	case strings.Contains(dtype, "REAL"), strings.Contains(dtype, "FLOA"), strings.Contains(dtype, "DOUB"):
+		return sqliteRealAffinity
+	default:
+		return sqliteNumericAffinity
+	}
+}
+
+func sqliteValue(column DBColumn, value interface{}) (interface{}, error) {
+	bts, isBytes := value.([]byte)
+	if !isBytes || sqliteAffinity(column.Type) == sqliteBlobAffinity {
+		return value, nil
+	}
+
+	return string(bts), nil
+}
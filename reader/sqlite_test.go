@@ -0,0 +1,188 @@
+package reader_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aureliano/db-unit-extractor/dataconv"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func newSQLiteTestReader(t *testing.T) (reader.DBReader, *reader.DataSource) {
+	ds := reader.NewDataSource()
+	ds.DSN = fmt.Sprintf("sqlite3://file:%s?mode=memory&cache=shared", t.Name())
+
+	r, err := reader.NewReader(ds)
+	require.Nil(t, err)
+
+	_, err = ds.DB.Exec(`CREATE TABLE orders (
+		id INTEGER PRIMARY KEY,
+		status VARCHAR(15),
+		total DECIMAL(17, 2) NOT NULL,
+		rate REAL,
+		created_at DATETIME,
+		attachment BLOB,
+		misc
+	)`)
+	require.Nil(t, err)
+
+	return r, ds
+}
+
+func TestSQLiteFetchColumnsMetadata(t *testing.T) {
+	r, ds := newSQLiteTestReader(t)
+	defer ds.DB.Close()
+
+	columns, err := r.FetchColumnsMetadata(schema.Table{
+		Name:    "orders",
+		Columns: []schema.Column{"ID", "status", "total", "misc"},
+	})
+
+	require.Nil(t, err)
+	assert.Len(t, columns, 4)
+
+	assert.Equal(t, "id", columns[0].Name)
+	assert.Equal(t, "INTEGER", columns[0].Type)
+	assert.Equal(t, false, columns[0].Nullable)
+	assert.Equal(t, true, columns[0].PrimaryKey)
+
+	assert.Equal(t, "status", columns[1].Name)
+	assert.Equal(t, "VARCHAR", columns[1].Type)
+	assert.Equal(t, true, columns[1].Nullable)
+	assert.Equal(t, false, columns[1].PrimaryKey)
+	assert.EqualValues(t, 15, columns[1].Length)
+
+	assert.Equal(t, "total", columns[2].Name)
+	assert.Equal(t, "DECIMAL", columns[2].Type)
+	assert.Equal(t, false, columns[2].Nullable)
+	assert.EqualValues(t, 17, columns[2].DecimalSize.Precision)
+	assert.EqualValues(t, 2, columns[2].DecimalSize.Scale)
+
+	assert.Equal(t, "misc", columns[3].Name)
+	assert.Equal(t, "BLOB", columns[3].Type)
+}
+
+func TestSQLiteFetchColumnsMetadataIgnore(t *testing.T) {
+	r, ds := newSQLiteTestReader(t)
+	defer ds.DB.Close()
+
+	columns, err := r.FetchColumnsMetadata(schema.Table{
+		Name:   "orders",
+		Ignore: []schema.Ignore{"status", "total", "rate", "created_at", "misc"},
+	})
+
+	require.Nil(t, err)
+	assert.Len(t, columns, 2)
+	assert.Equal(t, "id", columns[0].Name)
+	assert.Equal(t, "attachment", columns[1].Name)
+}
+
+func TestSQLiteFetchColumnsMetadataEmptyResultError(t *testing.T) {
+	r, ds := newSQLiteTestReader(t)
+	defer ds.DB.Close()
+
+	_, err := r.FetchColumnsMetadata(schema.Table{Name: "customers"})
+	assert.Equal(t, "no metadata found for table customers (make sure it exists and user has proper grants)", err.Error())
+}
+
+func TestSQLiteFetchColumnsMetadataQueryError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	require.Nil(t, err)
+	defer db.Close()
+
+	r, _ := reader.NewReader(&reader.DataSource{DSN: "sqlite3://file:test.db", DB: db})
+	sqlErr := errors.New("no such table-valued function")
+
+	mock.ExpectQuery("^SELECT (.+) FROM pragma_table_info").WillReturnError(sqlErr)
+
+	_, err = r.FetchColumnsMetadata(schema.Table{Name: "customers"})
+	assert.ErrorIs(t, err, sqlErr)
+}
+
+func TestSQLiteFetchData(t *testing.T) {
+	r, ds := newSQLiteTestReader(t)
+	defer ds.DB.Close()
+
+	createdAt := time.Date(2023, time.June, 9, 14, 31, 16, 0, time.UTC)
+	_, err := ds.DB.Exec("INSERT INTO orders VALUES(?, ?, ?, ?, ?, ?, ?)",
+		4, "SOLD", 2243.72, 0.5, createdAt, []byte("hello world"), []byte("raw"))
+	require.Nil(t, err)
+	_, err = ds.DB.Exec("INSERT INTO orders(id, status, total) VALUES(5, CAST('PENDING' AS BLOB), 10)")
+	require.Nil(t, err)
+
+	table := schema.Table{Name: "orders"}
+	fields, err := r.FetchColumnsMetadata(table)
+	require.Nil(t, err)
+
+	converters := []dataconv.Converter{dataconv.DateTimeISO8601Converter{}, dataconv.BlobConverter{}}
+	data, err := r.FetchData("orders", fields, converters, [][]interface{}{{"id", []interface{}{4, 5}}})
+	require.Nil(t, err)
+
+	assert.Len(t, data, 2)
+	assert.EqualValues(t, 4, data[0][0].Value)
+	assert.Equal(t, "SOLD", data[0][1].Value)
+	assert.EqualValues(t, 2243.72, data[0][2].Value)
+	assert.EqualValues(t, 0.5, data[0][3].Value)
+	assert.Equal(t, "2023-06-09T14:31:16 +0000", data[0][4].Value)
+	assert.Equal(t, "aGVsbG8gd29ybGQ=", data[0][5].Value)
+	assert.Equal(t, "cmF3", data[0][6].Value)
+
+	assert.EqualValues(t, 5, data[1][0].Value)
+	assert.Equal(t, "PENDING", data[1][1].Value)
+	assert.Nil(t, data[1][3].Value)
+}
+
+func TestSQLiteFetchDataPrepareError(t *testing.T) {
+	r, ds := newSQLiteTestReader(t)
+	defer ds.DB.Close()
+
+	fields := []reader.DBColumn{{Name: "id", Type: "INTEGER"}}
+
+	_, err := r.FetchData("customers", fields, []dataconv.Converter{}, [][]interface{}{})
+	assert.Contains(t, err.Error(), "no such table: customers")
+}
+
+func TestSQLiteFetchDataNotAllFieldsWereBound(t *testing.T) {
+	r, ds := newSQLiteTestReader(t)
+	defer ds.DB.Close()
+
+	fields := []reader.DBColumn{{Name: "id", Type: "INTEGER"}}
+	filters := [][]interface{}{{"id", nil}}
+
+	_, err := r.FetchData("orders", fields, []dataconv.Converter{}, filters)
+	assert.Equal(t, "not all filters were bound for table orders `[[id <nil>]]'", err.Error())
+}
+
+func TestSQLiteProfilerModeDisabled(t *testing.T) {
+	r := reader.SQLiteReader{}
+	assert.False(t, r.ProfilerMode())
+}
+
+func TestSQLiteStartDBProfiler(t *testing.T) {
+	prof := filepath.Join(os.TempDir(), "db.prof")
+	defer os.Remove(prof)
+	t.Setenv("DB_PROFILE", prof)
+	defer t.Setenv("DB_PROFILE", "")
+
+	r, ds := newSQLiteTestReader(t)
+	defer ds.DB.Close()
+	assert.True(t, r.ProfilerMode())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.StartDBProfiler(ctx)
+	time.Sleep(time.Millisecond * 500)
+
+	cancel()
+	time.Sleep(time.Millisecond * 500)
+}
@@ -0,0 +1,130 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/dataconv"
+	"github.com/aureliano/db-unit-extractor/schema"
+)
+
+type SQLServerReader struct {
+	db        *sql.DB
+	profiling *os.File
+}
+
+const uniqueIdentifierSize = 16
+
+func init() {
+	RegisterReader("sqlserver", newSQLServer)
+}
+
+func (r SQLServerReader) FetchColumnsMetadata(table schema.Table) ([]DBColumn, error) {
+	query := buildSQLServerSQLQueryColumnsMetadata(table)
+	return fetchColumnsMetadata(r.db, "SQLServer", table, query, isNullable)
+}
+
+func (r SQLServerReader) FetchData(table string, fields []DBColumn, converters []dataconv.Converter,
+	filters [][]interface{}) ([][]*DBColumn, error) {
+	query := buildSQLServerSQLQueryColumns(table, fields, filters)
+
+	arrValues, err := bindFilters(table, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchData(r.db, fields, converters, sqlserverValue, arrValues, query)
+}
+
+func (r SQLServerReader) ProfilerMode() bool {
+	return r.profiling != nil
+}
+
+func (r SQLServerReader) StartDBProfiler(ctx context.Context) {
+	startDBProfiler(ctx, r.db, r.profiling)
+}
+
+func newSQLServer(ds DBConnector) (DBReader, error) {
+	db, pfile, err := connect(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return SQLServerReader{db: db, profiling: pfile}, nil
+}
+
+func buildSQLServerSQLQueryColumnsMetadata(table schema.Table) string {
+	var builder strings.Builder
+	builder.WriteString("SELECT COLUMN_NAME, UPPER(DATA_TYPE), IS_NULLABLE, CHARACTER_MAXIMUM_LENGTH,")
+	builder.WriteString(" NUMERIC_PRECISION, NUMERIC_SCALE, ")
+	builder.WriteString(informationSchemaPrimaryKeyColumn)
+	builder.WriteString(" FROM INFORMATION_SCHEMA.COLUMNS WHERE")
+
+	owner, name := splitTableName(table.Name)
+	if owner == "" {
+		builder.WriteString(" TABLE_SCHEMA = SCHEMA_NAME()")
+	} else {
+		builder.WriteString(fmt.Sprintf(" TABLE_SCHEMA = '%s'", owner))
+	}
+
+	builder.WriteString(fmt.Sprintf(" AND TABLE_NAME = '%s'", name))
+	builder.WriteString(" AND COLUMNPROPERTY(OBJECT_ID(QUOTENAME(TABLE_SCHEMA) + '.' + QUOTENAME(TABLE_NAME)),")
+	builder.WriteString(" COLUMN_NAME, 'IsComputed') = 0")
+
+	if len(table.Columns) > 0 || len(table.Ignore) > 0 {
+		builder.WriteString(" AND COLUMN_NAME")
+
+		if len(table.Ignore) > 0 {
+			builder.WriteString(" NOT")
+		}
+
+		builder.WriteString(fmt.Sprintf(" IN(%s)", table.FormattedSelectColumns()))
+	}
+
+	builder.WriteString(" ORDER BY ORDINAL_POSITION")
+
+	return builder.String()
+}
+
+func buildSQLServerSQLQueryColumns(table string, fields []DBColumn, filters [][]interface{}) string {
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = quoteSQLServerIdentifier(field.Name)
+	}
+
+	return buildSQLQuery(quoteSQLServerIdentifier(table), columns, quoteFilters(filters, quoteSQLServerIdentifier),
+		func(i int) string { return fmt.Sprintf("@p%d", i) })
+}
+
+func quoteSQLServerIdentifier(name string) string {
+	return quoteIdentifier(name, "[", "]")
+}
+
+func sqlserverValue(column DBColumn, value interface{}) (interface{}, error) {
+	bts, isBytes := value.([]byte)
+	if !isBytes {
+		return value, nil
+	}
+
+	switch column.Type {
+	case "UNIQUEIDENTIFIER":
+		return sqlserverUniqueIdentifier(bts)
+	case "BINARY", "VARBINARY", "IMAGE", "TIMESTAMP", "ROWVERSION":
+		return value, nil
+	default:
+		return string(bts), nil
+	}
+}
+
+func sqlserverUniqueIdentifier(bts []byte) (string, error) {
+	if len(bts) != uniqueIdentifierSize {
+		return "", fmt.Errorf("'%v' is not a valid uniqueidentifier", bts)
+	}
+
+	return fmt.Sprintf("%X-%X-%X-%X-%X",
+		[]byte{bts[3], bts[2], bts[1], bts[0]}, []byte{bts[5], bts[4]}, []byte{bts[7], bts[6]},
+		bts[8:10], bts[10:]), nil
+}
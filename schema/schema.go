@@ -19,8 +19,9 @@ var (
 	ErrSchemaValidation      = errors.New("validation")
 	ErrTableClassification   = errors.New("classification")
 	nameRegExp               = regexp.MustCompile(`^[a-zA-Z_]\w+$`)
-	filterReferenceRegExp    = regexp.MustCompile(`^\$\{(\w+)\.(\w+(\[@\])?)\}$`)
-	multivaluedFilterRegeExp = regexp.MustCompile(`^\$\{(\w+)\.(\w+\[@\])\}$`)
+	tableNameRegExp          = regexp.MustCompile(`^([a-zA-Z_]\w*\.)?[a-zA-Z_]\w+$`)
+	filterReferenceRegExp    = regexp.MustCompile(`^\$\{((?:\w+\.)?\w+)\.(\w+(\[@\])?)\}$`)
+	multivaluedFilterRegeExp = regexp.MustCompile(`^\$\{((?:\w+\.)?\w+)\.(\w+\[@\])\}$`)
 )
 
 type Converter string
@@ -38,6 +39,7 @@ type Table struct {
 	Filters []Filter `yaml:"filters"`
 	Columns []Column `yaml:"columns"`
 	Ignore  []Ignore `yaml:"ignore"`
+	OrderBy []Column `yaml:"order_by"`
 }
 
 type Model struct {
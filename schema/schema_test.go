@@ -154,6 +154,19 @@ func TestDigestSchemaReferences(t *testing.T) {
 	assert.EqualValues(t, dataconv.BlobConverterID, schema.Converters[1])
 }
 
+func TestDigestSchemaQualifiedNames(t *testing.T) {
+	schema, err := schema.DigestSchema("../test/unit/schema_test_qualified_names.yml")
+	require.Nil(t, err)
+
+	assert.Len(t, schema.Refs, 2)
+	assert.Contains(t, schema.Refs, "dbo.customers.id")
+	assert.Contains(t, schema.Refs, "sales.orders.id[@]")
+
+	assert.Equal(t, 1, schema.Tables[0].GroupID)
+	assert.Equal(t, 2, schema.Tables[1].GroupID)
+	assert.Equal(t, 3, schema.Tables[2].GroupID)
+}
+
 func TestSelectColumns(t *testing.T) {
 	table := schema.Table{
 		Columns: []schema.Column{"id", "name", "description"},
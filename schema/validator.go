@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/aureliano/db-unit-extractor/dataconv"
@@ -26,7 +27,7 @@ func (c Converter) Validate() error {
 }
 
 func (t Table) Validate() error {
-	if err := validateName(t.Name); err != nil {
+	if err := validateTableName(t.Name); err != nil {
 		return err
 	}
 
@@ -72,7 +73,7 @@ func (t Table) Validate() error {
 		return fmt.Errorf("%w: repeated ignore column '%s' in table '%s", ErrSchemaValidation, c, t.Name)
 	}
 
-	return nil
+	return validateOrderBy(t)
 }
 
 func (f Filter) Validate() error {
@@ -91,6 +92,43 @@ func (c Ignore) Validate() error {
 	return validateName(string(c))
 }
 
+func validateOrderBy(t Table) error {
+	cols := make([]string, len(t.OrderBy))
+	for i, c := range t.OrderBy {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("table '%s' %w", t.Name, err)
+		}
+
+		if !selectedColumn(t, c) {
+			return fmt.Errorf("%w: order by column '%s' not selected in table '%s'", ErrSchemaValidation, c, t.Name)
+		}
+
+		cols[i] = string(c)
+	}
+
+	if c := repeatedValue(cols); c != "" {
+		return fmt.Errorf("%w: repeated order by column '%s' in table '%s'", ErrSchemaValidation, c, t.Name)
+	}
+
+	return nil
+}
+
+func selectedColumn(t Table, column Column) bool {
+	for _, c := range t.Columns {
+		if strings.EqualFold(string(c), string(column)) {
+			return true
+		}
+	}
+
+	for _, c := range t.Ignore {
+		if strings.EqualFold(string(c), string(column)) {
+			return false
+		}
+	}
+
+	return len(t.Columns) == 0
+}
+
 func validateTables(tables []Table) error {
 	if len(tables) == 0 {
 		return fmt.Errorf("%w: no table provided", ErrSchemaValidation)
@@ -187,11 +225,19 @@ func validateConverters(converters []Converter) error {
 }
 
 func validateName(name string) error {
+	return validateNamePattern(name, nameRegExp)
+}
+
+func validateTableName(name string) error {
+	return validateNamePattern(name, tableNameRegExp)
+}
+
+func validateNamePattern(name string, pattern *regexp.Regexp) error {
 	if len(name) > NameMaxLength {
 		return fmt.Errorf("%w: '%s' invalid name size", ErrSchemaValidation, name)
 	}
 
-	if !nameRegExp.MatchString(name) {
+	if !pattern.MatchString(name) {
 		return fmt.Errorf("%w: '%s' invalid name", ErrSchemaValidation, name)
 	}
 
@@ -132,6 +132,16 @@ func TestTableSchemaValidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestTableSchemaValidateQualifiedName(t *testing.T) {
+	s := schema.Table{Name: "dbo.tbl_1"}
+	assert.Nil(t, s.Validate())
+
+	s = schema.Table{Name: "db.dbo.tbl_1"}
+	err := s.Validate()
+	assert.ErrorIs(t, err, schema.ErrSchemaValidation)
+	assert.Contains(t, err.Error(), "'db.dbo.tbl_1' invalid name")
+}
+
 func TestTableSchemaValidateName(t *testing.T) {
 	s := schema.Table{}
 	err := s.Validate()
@@ -260,3 +270,32 @@ func TestIgnoreSchema(t *testing.T) {
 	assert.ErrorIs(t, err, schema.ErrSchemaValidation)
 	assert.Contains(t, err.Error(), "'' invalid name")
 }
+
+func TestTableSchemaValidateOrderBy(t *testing.T) {
+	s := schema.Table{Name: "tbl", Columns: []schema.Column{"a1", "b1"}, OrderBy: []schema.Column{"b1", "a1"}}
+	assert.Nil(t, s.Validate())
+
+	s = schema.Table{Name: "tbl", OrderBy: []schema.Column{"1a"}}
+	err := s.Validate()
+	assert.ErrorIs(t, err, schema.ErrSchemaValidation)
+	assert.Contains(t, err.Error(), "table 'tbl' validation: '1a' invalid name")
+}
+
+func TestTableSchemaValidateOrderByNotSelected(t *testing.T) {
+	s := schema.Table{Name: "tbl", Columns: []schema.Column{"a1", "b1"}, OrderBy: []schema.Column{"c1"}}
+	err := s.Validate()
+	assert.ErrorIs(t, err, schema.ErrSchemaValidation)
+	assert.Contains(t, err.Error(), "order by column 'c1' not selected in table 'tbl'")
+
+	s = schema.Table{Name: "tbl", Ignore: []schema.Ignore{"a1"}, OrderBy: []schema.Column{"A1"}}
+	err = s.Validate()
+	assert.ErrorIs(t, err, schema.ErrSchemaValidation)
+	assert.Contains(t, err.Error(), "order by column 'A1' not selected in table 'tbl'")
+}
+
+func TestTableSchemaValidateRepeatedOrderByColumn(t *testing.T) {
+	s := schema.Table{Name: "tbl", OrderBy: []schema.Column{"a1", "b1", "a1"}}
+	err := s.Validate()
+	assert.ErrorIs(t, err, schema.ErrSchemaValidation)
+	assert.Contains(t, err.Error(), "repeated order by column 'a1' in table 'tbl'")
+}
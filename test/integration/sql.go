@@ -1,14 +1,20 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"regexp"
 	"strings"
 )
 
 var (
-	insertRegExp = regexp.MustCompile(`(insert\sinto|INSERT\sINTO)\s(\w+)\(([^)]+)\)\s+(values|VALUES)\s*([^;]+)`)
-	spacesRegExp = regexp.MustCompile(`\s+`)
+	insertRegExp    = regexp.MustCompile(`(?is)^\s*insert\s+into\s+(\w+)\s*\(([^)]+)\)\s*values\s*(.+)$`)
+	spacesRegExp    = regexp.MustCompile(`\s+`)
+	dateTimeRegExp  = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})( 00:00:00(\.0+)?)?( [-+]\d{2}:\d{2})?$`)
+	fractionRegExp  = regexp.MustCompile(`\.0+$`)
+	dateFunctionSet = map[string]bool{
+		"TO_DATE": true, "TO_TIMESTAMP": true, "TO_TIMESTAMP_TZ": true, "DATE": true, "TIMESTAMP": true,
+	}
 )
 
 func parseSQL(path string) (*DataSet, error) {
@@ -17,76 +23,215 @@ func parseSQL(path string) (*DataSet, error) {
 		return nil, err
 	}
 
-	sql := string(byteValue)
-	inserts := strings.Split(sql, ";")
-	tables := make([]Table, 0, len(inserts))
-
-	for _, insert := range inserts {
-		res := insertRegExp.FindAllStringSubmatch(insert, -1)
-		if len(res) == 0 {
+	tables := make([]Table, 0)
+	for _, insert := range splitStatements(string(byteValue)) {
+		res := insertRegExp.FindStringSubmatch(insert)
+		if res == nil {
 			continue
 		}
-		tname := res[0][2]
-		fnames := strings.Split(res[0][3], ",")
-		arrvalues := findInsertValues(res[0][5])
 
-		fields := make([]Field, len(fnames))
-		for j, fname := range fnames {
-			fields[j] = Field{Name: spacesRegExp.ReplaceAllString(fname, "")}
+		tname := res[1]
+		fnames := strings.Split(res[2], ",")
+		arrvalues, err := findInsertValues(res[3])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tname, err)
 		}
 
 		for _, values := range arrvalues {
-			cpFields := make([]Field, len(fnames))
-			copy(cpFields, fields)
+			if len(values) != len(fnames) {
+				return nil, fmt.Errorf("%s: %d columns but %d values", tname, len(fnames), len(values))
+			}
 
+			fields := make([]Field, 0, len(fnames))
 			for i, value := range values {
-				field := &cpFields[i]
-				field.Value = value
+				if value != nil {
+					fields = append(fields, Field{Name: spacesRegExp.ReplaceAllString(fnames[i], ""), Value: *value})
+				}
 			}
 
-			table := Table{Name: tname, Fields: cpFields}
-			tables = append(tables, table)
+			tables = append(tables, Table{Name: tname, Fields: fields})
 		}
 	}
 
 	return &DataSet{Tables: tables}, nil
 }
 
-func findInsertValues(text string) [][]string {
-	re := regexp.MustCompile(`\)\s*\(`)
-	fre := regexp.MustCompile(`'([^']+)'`)
-	indexes := re.FindAllStringIndex(text, -1)
-	values := make([][]string, len(indexes)+1)
-	lines := make([]string, len(indexes)+1)
-
-	if len(indexes) == 0 {
-		lines[0] = text
-	} else {
-		lines[0] = text[:indexes[0][0]]
-
-		size := len(indexes)
-		for i := 0; i < size; i++ {
-			if i+1 < size {
-				begin := indexes[i][0]
-				end := indexes[i+1][0]
-				lines[i+1] = text[begin:end]
+func splitStatements(sql string) []string {
+	statements := make([]string, 0)
+	quoted := false
+	begin := 0
+
+	for i, c := range sql {
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == ';' && !quoted:
+			statements = append(statements, sql[begin:i])
+			begin = i + 1
+		}
+	}
+
+	return append(statements, sql[begin:])
+}
+
+func findInsertValues(text string) ([][]*string, error) {
+	p := sqlParser{text: text}
+	rows := make([][]*string, 0)
+
+	for {
+		if !p.consume('(') {
+			return nil, p.errorf("expected '('")
+		}
+
+		row := make([]*string, 0)
+		for {
+			value, err := p.literal()
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, value)
+
+			if p.consume(')') {
+				break
+			}
+
+			if !p.consume(',') {
+				return nil, p.errorf("expected ',' or ')'")
 			}
 		}
+		rows = append(rows, row)
+
+		if !p.consume(',') {
+			break
+		}
+	}
+
+	if p.skipSpaces(); p.pos < len(p.text) {
+		return nil, p.errorf("unexpected content")
+	}
+
+	return rows, nil
+}
+
+type sqlParser struct {
+	text string
+	pos  int
+}
+
+func (p *sqlParser) errorf(msg string) error {
+	return fmt.Errorf("%s at position %d of values %q", msg, p.pos, p.text)
+}
+
+func (p *sqlParser) skipSpaces() {
+	for p.pos < len(p.text) && strings.ContainsRune(" \t\r\n", rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *sqlParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.text) && p.text[p.pos] == c {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *sqlParser) literal() (*string, error) {
+	p.skipSpaces()
+	if p.pos < len(p.text) && p.text[p.pos] == '\'' {
+		value, err := p.quoted()
+		return &value, err
+	}
+
+	begin := p.pos
+	for p.pos < len(p.text) && !strings.ContainsRune(" \t\r\n,()'", rune(p.text[p.pos])) {
+		p.pos++
+	}
+	word := p.text[begin:p.pos]
+
+	switch {
+	case word == "":
+		return nil, p.errorf("expected a value")
+	case strings.EqualFold(word, "null"):
+		return nil, nil
+	case strings.EqualFold(word, "true") || strings.EqualFold(word, "false"):
+		word = strings.ToLower(word)
+		return &word, nil
+	case p.consume('('):
+		return p.function(word)
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.text) && p.text[p.pos] == '\'' {
+		value, err := p.quoted()
+		if dateFunctionSet[strings.ToUpper(word)] {
+			value = dateText(value)
+		}
 
-		index := indexes[size-1][0]
-		lines[len(lines)-1] = text[index:]
+		return &value, err
 	}
 
-	for i, line := range lines {
-		fields := fre.FindAllStringSubmatch(line, -1)
-		source := make([]string, len(fields))
+	return &word, nil
+}
+
+func (p *sqlParser) function(name string) (*string, error) {
+	args := make([]*string, 0)
+	for {
+		value, err := p.literal()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
+
+		if p.consume(')') {
+			break
+		}
 
-		for j, value := range fields {
-			source[j] = value[1]
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' or ')'")
 		}
+	}
+
+	value := args[0]
+	if value != nil && dateFunctionSet[strings.ToUpper(name)] {
+		text := dateText(*value)
+		value = &text
+	}
+
+	return value, nil
+}
+
+func (p *sqlParser) quoted() (string, error) {
+	sb := strings.Builder{}
+	p.pos++
+
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		p.pos++
+
+		if c != '\'' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		if p.pos < len(p.text) && p.text[p.pos] == '\'' {
+			sb.WriteByte(c)
+			p.pos++
+			continue
+		}
+
+		return sb.String(), nil
+	}
+
+	return "", p.errorf("unterminated string")
+}
 
-		values[i] = source
+func dateText(value string) string {
+	if res := dateTimeRegExp.FindStringSubmatch(value); res != nil && res[2] != "" {
+		return res[1]
 	}
 
-	return values
+	return fractionRegExp.ReplaceAllString(value, "")
 }
@@ -0,0 +1,89 @@
+package writer
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+type outputFile struct {
+	file       *os.File
+	compressor io.WriteCloser
+}
+
+var (
+	ErrUnsupportedCompression = errors.New("unsupported compression")
+	compressionExtensions     = map[string]string{"gzip": ".gz", "zstd": ".zst"}
+)
+
+func CompressionTypes() []string {
+	return []string{"gzip", "zstd"}
+}
+
+func (f *outputFile) Write(content []byte) (int, error) {
+	if f == nil || f.compressor == nil {
+		return f.osFile().Write(content)
+	}
+
+	return f.compressor.Write(content)
+}
+
+func (f *outputFile) Close() error {
+	if f == nil || f.compressor == nil {
+		return f.osFile().Close()
+	}
+
+	err := f.compressor.Close()
+	if e := f.file.Close(); e != nil && err == nil {
+		err = e
+	}
+
+	return err
+}
+
+func (f *outputFile) osFile() *os.File {
+	if f == nil {
+		return nil
+	}
+
+	return f.file
+}
+
+func supportedCompression(compression string) bool {
+	_, supported := compressionExtensions[strings.ToLower(compression)]
+	return compression == "" || supported
+}
+
+func createFile(path, compression string) (*outputFile, error) {
+	if !supportedCompression(compression) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, compression)
+	}
+
+	compression = strings.ToLower(compression)
+	file, err := os.Create(path + compressionExtensions[compression])
+	if err != nil {
+		return nil, err
+	}
+
+	switch compression {
+	case "gzip":
+		gz := gzip.NewWriter(file)
+		gz.Name = filepath.Base(path)
+		return &outputFile{file: file, compressor: gz}, nil
+	case "zstd":
+		zw, err := zstd.NewWriter(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		return &outputFile{file: file, compressor: zw}, nil
+	default:
+		return &outputFile{file: file}, nil
+	}
+}
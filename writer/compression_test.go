@@ -0,0 +1,112 @@
+package writer_test
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriterUnsupportedCompression(t *testing.T) {
+	_, err := writer.NewWriter(writer.FileConf{Type: "xml", Compression: "bzip2"})
+	assert.ErrorIs(t, err, writer.ErrUnsupportedCompression)
+	assert.Equal(t, "unsupported compression: bzip2", err.Error())
+}
+
+func TestCompressionTypes(t *testing.T) {
+	assert.Equal(t, []string{"gzip", "zstd"}, writer.CompressionTypes())
+}
+
+func TestCompressionWriteHeaderUnsupportedCompression(t *testing.T) {
+	w := writer.SQLWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "test-compression-unsupported",
+		Compression: "bzip2",
+	}
+
+	assert.True(t, errors.Is(w.WriteHeader(), writer.ErrUnsupportedCompression))
+}
+
+func TestCompressionGzipXML(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w, err := writer.NewWriter(writer.FileConf{Type: "xml", Directory: dir, Name: "test-compression-gzip",
+		Compression: "gzip"})
+	require.Nil(t, err)
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}))
+	require.Nil(t, w.WriteFooter())
+
+	file, err := os.Open(filepath.Join(dir, "test-compression-gzip.xml.gz"))
+	require.Nil(t, err)
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	require.Nil(t, err)
+	assert.Equal(t, "test-compression-gzip.xml", gz.Name)
+
+	content, err := io.ReadAll(gz)
+	require.Nil(t, err)
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<!DOCTYPE dataset SYSTEM \"test-compression-gzip.dtd\"><dataset><products id=\"1\"/></dataset>", string(content))
+
+	_, err = os.Stat(filepath.Join(dir, "test-compression-gzip.dtd"))
+	assert.Nil(t, err)
+}
+
+func TestCompressionZstdSQL(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w, err := writer.NewWriter(writer.FileConf{Type: "sql", Directory: dir, Name: "test-compression-zstd",
+		Compression: "ZSTD"})
+	require.Nil(t, err)
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}))
+	require.Nil(t, w.WriteFooter())
+
+	file, err := os.Open(filepath.Join(dir, "test-compression-zstd.sql.zst"))
+	require.Nil(t, err)
+	defer file.Close()
+
+	zr, err := zstd.NewReader(file)
+	require.Nil(t, err)
+	defer zr.Close()
+
+	content, err := io.ReadAll(zr)
+	require.Nil(t, err)
+	assert.Equal(t, "insert into products(id) values(1);", string(content))
+}
+
+func TestCompressionGzipCSV(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.CSVWriter{Directory: dir, Name: "test-compression-csv", Compression: "gzip"}
+	defer os.RemoveAll(filepath.Join(dir, w.Name))
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}))
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 2}}}))
+	require.Nil(t, w.WriteFooter())
+
+	file, err := os.Open(filepath.Join(dir, w.Name, "products.csv.gz"))
+	require.Nil(t, err)
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	require.Nil(t, err)
+
+	content, err := io.ReadAll(gz)
+	require.Nil(t, err)
+	assert.Equal(t, "id\r\n1\r\n2\r\n", string(content))
+
+	bytes, err := os.ReadFile(filepath.Join(dir, w.Name, "table-ordering.txt"))
+	require.Nil(t, err)
+	assert.Equal(t, fmt.Sprintln("products"), string(bytes))
+}
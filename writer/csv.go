@@ -0,0 +1,141 @@
+package writer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type CSVWriter struct {
+	Directory   string
+	Name        string
+	Compression string
+	NullToken   string
+	TableOrder  []string
+	files       map[string]*outputFile
+	tables      []string
+}
+
+const (
+	csvDefaultNullToken  = "null"
+	csvTableOrderingFile = "table-ordering.txt"
+	csvLineBreak         = "\r\n"
+)
+
+func (w *CSVWriter) WriteHeader() error {
+	dir := w.directory()
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		log.Printf("CSV.WriteHeader\nMake directory %s failed with `%s'\n", dir, err.Error())
+		return err
+	}
+
+	w.files = make(map[string]*outputFile)
+	w.tables = make([]string, 0)
+
+	return nil
+}
+
+func (w *CSVWriter) WriteFooter() error {
+	content := csvTableOrdering(w.TableOrder, w.tables)
+	path := filepath.Join(w.directory(), csvTableOrderingFile)
+	err := os.WriteFile(path, content, os.ModePerm)
+	if err != nil {
+		log.Printf("CSV.WriteFooter\nWriting to file %s failed: `%s'\nContent: %s\n", path, err.Error(), content)
+	}
+
+	for _, table := range w.tables {
+		if e := w.files[table].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+func (w *CSVWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	file, exists := w.files[table]
+	if !exists {
+		path := filepath.Join(w.directory(), fmt.Sprintf("%s.csv", table))
+		var err error
+		file, err = createFile(path, w.Compression)
+		if err != nil {
+			log.Printf("CSV.Write\nFile %s not created: `%s'\n", path, err.Error())
+			return err
+		}
+
+		w.files[table] = file
+		w.tables = append(w.tables, table)
+	}
+
+	content := csvFileBody(!exists, w.nullToken(), rows)
+	_, err := file.Write(content)
+	if err != nil {
+		log.Printf("CSV.Write\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+	}
+
+	return err
+}
+
+func (w *CSVWriter) directory() string {
+	return filepath.Join(w.Directory, w.Name)
+}
+
+func (w *CSVWriter) nullToken() string {
+	if w.NullToken == "" {
+		return csvDefaultNullToken
+	}
+
+	return w.NullToken
+}
+
+func csvTableOrdering(order, tables []string) []byte {
+	sb := strings.Builder{}
+	for _, table := range orderedTables(order, tables) {
+		sb.WriteString(fmt.Sprintln(table))
+	}
+
+	return []byte(sb.String())
+}
+
+func csvFileBody(header bool, nullToken string, rows [][]*reader.DBColumn) []byte {
+	sb := strings.Builder{}
+
+	if header {
+		names := make([]string, len(rows[0]))
+		for i, column := range rows[0] {
+			names[i] = csvField(column.Name, nullToken)
+		}
+		sb.WriteString(strings.Join(names, ",") + csvLineBreak)
+	}
+
+	for _, row := range rows {
+		fields := make([]string, len(row))
+		for i, column := range row {
+			if column.Value == nil {
+				fields[i] = nullToken
+			} else {
+				fields[i] = csvField(textValue(column), nullToken)
+			}
+		}
+		sb.WriteString(strings.Join(fields, ",") + csvLineBreak)
+	}
+
+	return []byte(sb.String())
+}
+
+func csvField(value, nullToken string) string {
+	if value == nullToken || strings.ContainsAny(value, ",\"\r\n") {
+		return fmt.Sprintf("\"%s\"", strings.ReplaceAll(value, "\"", "\"\""))
+	}
+
+	return value
+}
@@ -0,0 +1,152 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.CSVWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestCSVWriteFileCreationError(t *testing.T) {
+	w := writer.CSVWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "csv-error"}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file creation error", w.Write("products", [][]*reader.DBColumn{{}}).Error())
+}
+
+func TestCSVWriteBodyFileWritingError(t *testing.T) {
+	w := writer.CSVWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "csv-error"}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.Write("products", [][]*reader.DBColumn{{}}).Error())
+}
+
+func TestCSVWriteFooterFileWritingError(t *testing.T) {
+	w := writer.CSVWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "csv-error"}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyFunc(os.WriteFile, func(string, []byte, fs.FileMode) error {
+		return fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestCSVWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.CSVWriter{Directory: dir, Name: "test-write-csv-empty"}
+	defer os.RemoveAll(filepath.Join(dir, w.Name))
+
+	assert.Nil(t, w.WriteHeader())
+
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	assert.NoFileExists(t, filepath.Join(dir, w.Name, "products.csv"))
+	bytes, _ := os.ReadFile(filepath.Join(dir, w.Name, "table-ordering.txt"))
+	assert.Empty(t, string(bytes))
+}
+
+func TestCSVWrite(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.CSVWriter{Directory: dir, Name: "test-write-csv", TableOrder: []string{"categories", "products"}}
+	defer os.RemoveAll(filepath.Join(dir, w.Name))
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{{
+		{Name: "id", Value: 1},
+		{Name: "name", Value: "shirt"},
+		{Name: "description", Value: "black \"slim\" shirt, size M"},
+		{Name: "price", Value: 14.50},
+	}, {
+		{Name: "id", Value: 2},
+		{Name: "name", Value: "null"},
+		{Name: "description"},
+		{Name: "price", Value: 26.35},
+	}}
+
+	assert.Nil(t, w.Write("products", rows))
+	assert.Nil(t, w.Write("reviews", [][]*reader.DBColumn{{{Name: "text", Value: "line 1\nline 2"}}}))
+	assert.Nil(t, w.Write("categories", [][]*reader.DBColumn{{{Name: "id", Value: 7}}}))
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{
+		{Name: "id", Value: 3}, {Name: "name", Value: "cap"}, {Name: "description"}, {Name: "price", Value: 5},
+	}}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, w.Name, "products.csv"))
+	expected := "id,name,description,price\r\n" +
+		"1,shirt,\"black \"\"slim\"\" shirt, size M\",14.5\r\n" +
+		"2,\"null\",null,26.35\r\n" +
+		"3,cap,null,5\r\n"
+	assert.Equal(t, expected, string(bytes))
+
+	bytes, _ = os.ReadFile(filepath.Join(dir, w.Name, "reviews.csv"))
+	assert.Equal(t, "text\r\n\"line 1\nline 2\"\r\n", string(bytes))
+
+	bytes, _ = os.ReadFile(filepath.Join(dir, w.Name, "table-ordering.txt"))
+	assert.Equal(t, "categories\nproducts\nreviews\n", string(bytes))
+}
+
+func TestCSVWriteBinary(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.CSVWriter{Directory: dir, Name: "test-write-csv-binary"}
+	defer os.RemoveAll(filepath.Join(dir, w.Name))
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("documents", [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "content", Type: "BLOB", Value: []byte{1, 2, 3}},
+	}}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, w.Name, "documents.csv"))
+	assert.Equal(t, "id,content\r\n1,AQID\r\n", string(bytes))
+}
+
+func TestCSVWriteNullToken(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.CSVWriter{Directory: dir, Name: "test-write-csv-null", NullToken: "[NULL]"}
+	defer os.RemoveAll(filepath.Join(dir, w.Name))
+
+	assert.Nil(t, w.WriteHeader())
+
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{
+		{Name: "id", Value: 1}, {Name: "name", Value: "null"}, {Name: "description"}, {Name: "code", Value: "[NULL]"},
+	}}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, w.Name, "products.csv"))
+	assert.Equal(t, "id,name,description,code\r\n1,null,[NULL],\"[NULL]\"\r\n", string(bytes))
+}
@@ -0,0 +1,394 @@
+package writer
+
+import (
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type GoWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	Package     string
+	TableOrder  []string
+	file        *outputFile
+	tables      []string
+	records     map[string][][]*reader.DBColumn
+}
+
+type goKind int
+
+type goField struct {
+	name     string
+	kind     goKind
+	nullable bool
+	typed    bool
+}
+
+type goSource struct {
+	formatted bool
+	names     map[string]bool
+	imports   map[string]bool
+	body      strings.Builder
+}
+
+const (
+	goString goKind = iota
+	goBool
+	goInt
+	goFloat
+	goTime
+	goBytes
+)
+
+const (
+	goDefaultPackage = "fixtures"
+	goRowsSuffix     = "Rows"
+)
+
+var (
+	goInitialisms = map[string]bool{
+		"ID": true, "UID": true, "UUID": true, "URL": true, "URI": true, "IP": true, "API": true, "HTTP": true,
+		"JSON": true, "XML": true, "SQL": true,
+	}
+	goIntegerTypes = map[string]bool{
+		"INT": true, "INTEGER": true, "INT2": true, "INT4": true, "INT8": true, "SMALLINT": true, "TINYINT": true,
+		"MEDIUMINT": true, "BIGINT": true, "SERIAL": true, "BIGSERIAL": true,
+	}
+	goDecimalTypes = map[string]bool{"NUMBER": true, "NUMERIC": true, "DECIMAL": true, "DEC": true}
+	goTypes        = map[goKind][]string{
+		goString: {"string", "sql.NullString", "String"},
+		goBool:   {"bool", "sql.NullBool", "Bool"},
+		goInt:    {"int64", "sql.NullInt64", "Int64"},
+		goFloat:  {"float64", "sql.NullFloat64", "Float64"},
+		goTime:   {"time.Time", "sql.NullTime", "Time"},
+		goBytes:  {"[]byte", "[]byte", ""},
+	}
+)
+
+func (w *GoWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("Go.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s.go", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("Go.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.tables = make([]string, 0)
+	w.records = make(map[string][][]*reader.DBColumn)
+
+	return nil
+}
+
+func (w *GoWriter) WriteFooter() error {
+	content, err := w.source()
+	if err != nil {
+		log.Printf("Go.WriteFooter\nFormatting source failed: `%s'\n", err.Error())
+		_ = w.file.Close()
+		return err
+	}
+
+	_, err = w.file.Write(content)
+	if err != nil {
+		log.Printf("Go.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *GoWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, exists := w.records[table]; !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	w.records[table] = append(w.records[table], rows...)
+
+	return nil
+}
+
+func (w *GoWriter) source() ([]byte, error) {
+	src := goSource{formatted: w.Formatted, names: make(map[string]bool), imports: make(map[string]bool)}
+	for _, table := range orderedTables(w.TableOrder, w.tables) {
+		src.writeTable(table, w.records[table])
+	}
+
+	pkg := w.Package
+	if pkg == "" {
+		pkg = goDefaultPackage
+	}
+
+	code := fmt.Sprintf("// Code generated by db-unit-extractor. DO NOT EDIT.\n\npackage %s\n%s%s", pkg,
+		src.importDecl(), src.body.String())
+
+	return format.Source([]byte(code))
+}
+
+func (s *goSource) writeTable(table string, rows [][]*reader.DBColumn) {
+	fields, index := goFields(rows)
+	name := s.typeName(table)
+
+	s.body.WriteString(fmt.Sprintf("\ntype %s struct {\n", name))
+	for _, field := range fields {
+		s.body.WriteString(fmt.Sprintf("\t%s %s\n", field.name, s.fieldType(field)))
+	}
+
+	s.body.WriteString(fmt.Sprintf("}\n\nvar %s%s = []%s{\n", name, goRowsSuffix, name))
+	for _, row := range rows {
+		values := make([]string, 0, len(row))
+		for _, column := range row {
+			if column.Value != nil {
+				field := index[column.Name]
+				values = append(values, fmt.Sprintf("%s: %s", field.name, s.value(field, column)))
+			}
+		}
+
+		if s.formatted && len(values) > 0 {
+			s.body.WriteString(fmt.Sprintf("\t{\n\t\t%s,\n\t},\n", strings.Join(values, ",\n\t\t")))
+		} else {
+			s.body.WriteString(fmt.Sprintf("\t{%s},\n", strings.Join(values, ", ")))
+		}
+	}
+
+	s.body.WriteString("}\n")
+}
+
+func (s *goSource) typeName(table string) string {
+	name := goIdentifier(table)
+	candidate := name
+	for i := 2; s.names[candidate] || s.names[candidate+goRowsSuffix]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+
+	s.names[candidate] = true
+	s.names[candidate+goRowsSuffix] = true
+
+	return candidate
+}
+
+func (s *goSource) fieldType(field *goField) string {
+	if field.kind == goTime && !field.nullable {
+		s.imports["time"] = true
+	}
+
+	if field.nullable && field.kind != goBytes {
+		s.imports["database/sql"] = true
+		return goTypes[field.kind][1]
+	}
+
+	return goTypes[field.kind][0]
+}
+
+func (s *goSource) value(field *goField, column *reader.DBColumn) string {
+	literal, _ := goLiteral(field.kind, column)
+	if field.kind == goTime {
+		s.imports["time"] = true
+	}
+
+	if !field.nullable || field.kind == goBytes {
+		return literal
+	}
+
+	return fmt.Sprintf("%s{%s: %s, Valid: true}", goTypes[field.kind][1], goTypes[field.kind][2], literal)
+}
+
+func (s *goSource) importDecl() string {
+	if len(s.imports) == 0 {
+		return ""
+	}
+
+	paths := make([]string, 0, len(s.imports))
+	for path := range s.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	sb := strings.Builder{}
+	sb.WriteString("\nimport (\n")
+	for _, path := range paths {
+		sb.WriteString(fmt.Sprintf("\t%q\n", path))
+	}
+	sb.WriteString(")\n")
+
+	return sb.String()
+}
+
+func goFields(rows [][]*reader.DBColumn) ([]*goField, map[string]*goField) {
+	fields := make([]*goField, 0)
+	index := make(map[string]*goField)
+	names := make(map[string]bool)
+
+	for _, row := range rows {
+		for _, column := range row {
+			field, exists := index[column.Name]
+			if !exists {
+				field = &goField{name: goFieldName(names, goIdentifier(column.Name))}
+				index[column.Name] = field
+				fields = append(fields, field)
+			}
+
+			if !field.typed {
+				field.kind = goColumnKind(column)
+				field.typed = column.Type != "" || column.Value != nil
+			}
+			field.nullable = field.nullable || column.Nullable || column.Value == nil
+		}
+	}
+
+	for _, row := range rows {
+		for _, column := range row {
+			field := index[column.Name]
+			if _, valid := goLiteral(field.kind, column); column.Value != nil && !valid {
+				field.kind = goString
+			}
+		}
+	}
+
+	return fields, index
+}
+
+func goFieldName(names map[string]bool, name string) string {
+	candidate := name
+	for i := 2; names[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	names[candidate] = true
+
+	return candidate
+}
+
+func goIdentifier(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	sb := strings.Builder{}
+	for _, part := range parts {
+		if strings.ToUpper(part) == part {
+			part = strings.ToLower(part)
+		}
+
+		if goInitialisms[strings.ToUpper(part)] {
+			sb.WriteString(strings.ToUpper(part))
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(part)
+		sb.WriteRune(unicode.ToUpper(r))
+		sb.WriteString(part[size:])
+	}
+
+	identifier := sb.String()
+	if r, _ := utf8.DecodeRuneInString(identifier); !unicode.IsUpper(r) {
+		identifier = "X" + identifier
+	}
+
+	return identifier
+}
+
+func goColumnKind(column *reader.DBColumn) goKind {
+	if column.Type == "" {
+		return goValueKind(column.Value)
+	}
+
+	tp := strings.ToUpper(column.Type)
+	switch {
+	case tp == "BOOLEAN" || tp == "BOOL":
+		return goBool
+	case goIntegerTypes[tp]:
+		return goInt
+	case goDecimalTypes[tp] && column.DecimalSize.Precision > 0 && column.DecimalSize.Scale == 0:
+		return goInt
+	case isNumericType(tp):
+		return goFloat
+	case isDateType(tp):
+		return goTime
+	case isBinaryType(tp):
+		return goBytes
+	default:
+		return goString
+	}
+}
+
+func goValueKind(value interface{}) goKind {
+	switch value.(type) {
+	case bool:
+		return goBool
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return goInt
+	case float32, float64:
+		return goFloat
+	case time.Time:
+		return goTime
+	case []byte:
+		return goBytes
+	default:
+		return goString
+	}
+}
+
+func goLiteral(kind goKind, column *reader.DBColumn) (string, bool) {
+	switch kind {
+	case goBool:
+		value, isBool := booleanValue(column)
+		return strconv.FormatBool(value), isBool
+	case goInt:
+		value, isNumber := numericValue(column)
+		_, err := strconv.ParseInt(value, 10, 64)
+		return value, isNumber && err == nil
+	case goFloat:
+		value, isNumber := numericValue(column)
+		_, err := strconv.ParseFloat(value, 64)
+		return value, isNumber && err == nil
+	case goTime:
+		value, isDate := dateValue(column)
+		return goTimeLiteral(value), isDate
+	case goBytes:
+		value, isBinary := binaryValue(column)
+		return goBytesLiteral(value), isBinary
+	default:
+		return strconv.Quote(textValue(column)), true
+	}
+}
+
+func goTimeLiteral(tm time.Time) string {
+	location := "time.UTC"
+	if zone, offset := tm.Zone(); offset != 0 {
+		location = fmt.Sprintf("time.FixedZone(%q, %d)", zone, offset)
+	}
+
+	return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)", tm.Year(), tm.Month(), tm.Day(), tm.Hour(),
+		tm.Minute(), tm.Second(), tm.Nanosecond(), location)
+}
+
+func goBytesLiteral(value []byte) string {
+	bts := make([]string, len(value))
+	for i, b := range value {
+		bts[i] = fmt.Sprintf("0x%02x", b)
+	}
+
+	return fmt.Sprintf("[]byte{%s}", strings.Join(bts, ", "))
+}
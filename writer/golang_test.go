@@ -0,0 +1,211 @@
+package writer_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.GoWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestGoWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.GoWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestGoWriteFooterFileWritingError(t *testing.T) {
+	w := writer.GoWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-go-writing-error",
+	}
+	require.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestGoWriteFooterInvalidPackage(t *testing.T) {
+	w := writer.GoWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-go-invalid-package", Package: "my-fixtures",
+	}
+	require.Nil(t, w.WriteHeader())
+
+	assert.NotNil(t, w.WriteFooter())
+}
+
+func TestGoWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.GoWriter{Directory: dir, Name: "test-go-empty"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.go", w.Name)))
+	assert.Equal(t, "// Code generated by db-unit-extractor. DO NOT EDIT.\n\npackage fixtures\n", string(bytes))
+}
+
+func TestGoWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.GoWriter{
+		Directory: dir, Name: "test-go-unformatted", Package: "testdata", TableOrder: []string{"shop.customers"},
+	}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{{
+		{Name: "ID", Type: "NUMBER", DecimalSize: reader.DecimalColumn{Precision: 10}, Value: "1"},
+		{Name: "CUSTOMER_ID", Type: "INTEGER", Nullable: true, Value: 34},
+		{Name: "paid", Type: "BOOLEAN", Value: true},
+		{Name: "total", Type: "DECIMAL", DecimalSize: reader.DecimalColumn{Precision: 10, Scale: 2}, Value: "10.50"},
+		{Name: "created_at", Type: "TIMESTAMP", Value: "2023-06-09T14:31:16.478 -0300"},
+		{Name: "due", Type: "DATE", Nullable: true, Value: time.Date(2023, time.June, 9, 0, 0, 0, 0, time.UTC)},
+		{Name: "notes", Type: "VARCHAR", Nullable: true, Value: nil},
+		{Name: "receipt", Type: "BLOB", Nullable: true, Value: []byte{0xDE, 0xAD}},
+	}}))
+	require.Nil(t, w.Write("shop.customers", [][]*reader.DBColumn{{
+		{Name: "id", Value: 34}, {Name: "name", Value: "Jane \"JJ\""}, {Name: "code", Type: "INTEGER", Value: "A1"},
+	}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.go", w.Name)))
+	require.Nil(t, err)
+
+	expected := `// Code generated by db-unit-extractor. DO NOT EDIT.
+
+package testdata
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ShopCustomers struct {
+	ID   int64
+	Name string
+	Code string
+}
+
+var ShopCustomersRows = []ShopCustomers{
+	{ID: 34, Name: "Jane \"JJ\"", Code: "A1"},
+}
+
+type Orders struct {
+	ID         int64
+	CustomerID sql.NullInt64
+	Paid       bool
+	Total      float64
+	CreatedAt  time.Time
+	Due        sql.NullTime
+	Notes      sql.NullString
+	Receipt    []byte
+}
+
+var OrdersRows = []Orders{
+	{ID: 1, CustomerID: sql.NullInt64{Int64: 34, Valid: true}, Paid: true, Total: 10.50, ` +
+		`CreatedAt: time.Date(2023, time.June, 9, 14, 31, 16, 478000000, time.FixedZone("", -10800)), ` +
+		`Due: sql.NullTime{Time: time.Date(2023, time.June, 9, 0, 0, 0, 0, time.UTC), Valid: true}, ` +
+		`Receipt: []byte{0xde, 0xad}},
+}
+`
+	assert.Equal(t, expected, string(bytes))
+	assertGoSourceCompiles(t, bytes)
+}
+
+func TestGoWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.GoWriter{Formatted: true, Directory: dir, Name: "test-go-formatted"}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{
+		{{Name: "id", Value: 1}, {Name: "name", Value: "shirt"}, {Name: "1st-price", Value: 9.9}},
+		{{Name: "id", Value: 2}, {Name: "name", Value: nil}},
+	}))
+	require.Nil(t, w.Write("Products", [][]*reader.DBColumn{{{Name: "id", Value: 3}, {Name: "ID", Value: 4}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.go", w.Name)))
+	require.Nil(t, err)
+
+	expected := `// Code generated by db-unit-extractor. DO NOT EDIT.
+
+package fixtures
+
+import (
+	"database/sql"
+)
+
+type Products struct {
+	ID        int64
+	Name      sql.NullString
+	X1stPrice float64
+}
+
+var ProductsRows = []Products{
+	{
+		ID:        1,
+		Name:      sql.NullString{String: "shirt", Valid: true},
+		X1stPrice: 9.9,
+	},
+	{
+		ID: 2,
+	},
+}
+
+type Products2 struct {
+	ID  int64
+	ID2 int64
+}
+
+var Products2Rows = []Products2{
+	{
+		ID:  3,
+		ID2: 4,
+	},
+}
+`
+	assert.Equal(t, expected, string(bytes))
+	assertGoSourceCompiles(t, bytes)
+}
+
+func assertGoSourceCompiles(t *testing.T, source []byte) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fixtures.go", source, parser.AllErrors)
+	require.Nil(t, err)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,147 @@
+package writer
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type HTMLWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	NullToken   string
+	file        *outputFile
+	report      report
+}
+
+const htmlStyle = "table{border-collapse:collapse}th,td{border:1px solid #ccc;padding:2px 6px}.null{color:#999}"
+
+func (w *HTMLWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("HTML.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s.html", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("HTML.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.report = report{}
+
+	return nil
+}
+
+func (w *HTMLWriter) WriteFooter() error {
+	content := []byte(w.document())
+	_, err := w.file.Write(content)
+	if err != nil {
+		log.Printf("HTML.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *HTMLWriter) WriteSummary(summary TableSummary) error {
+	w.report.addSummary(summary)
+	return nil
+}
+
+func (w *HTMLWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	w.report.addRows(table, rows)
+	return nil
+}
+
+func (w *HTMLWriter) document() string {
+	name := html.EscapeString(w.Name)
+	sb := strings.Builder{}
+	sb.WriteString("<!DOCTYPE html>")
+	sb.WriteString(fmt.Sprintf("%s<html>%s<head>%s<meta charset=\"UTF-8\">%s<title>%s</title>",
+		xmlIndent(w.Formatted, 0), xmlIndent(w.Formatted, 1), xmlIndent(w.Formatted, 2), xmlIndent(w.Formatted, 2),
+		name))
+	sb.WriteString(fmt.Sprintf("%s<style>%s</style>%s</head>%s<body>", xmlIndent(w.Formatted, 2), htmlStyle,
+		xmlIndent(w.Formatted, 1), xmlIndent(w.Formatted, 1)))
+	sb.WriteString(fmt.Sprintf("%s<h1>%s</h1>%s<h2>Summary</h2>", xmlIndent(w.Formatted, 2), name,
+		xmlIndent(w.Formatted, 2)))
+
+	summary := make([][]string, len(w.report.sections))
+	for i, section := range w.report.sections {
+		filters := section.filters()
+		for j := range filters {
+			filters[j] = html.EscapeString(filters[j])
+		}
+
+		summary[i] = []string{
+			fmt.Sprintf("<td>%s</td>", html.EscapeString(section.table)),
+			fmt.Sprintf("<td>%s</td>", section.group()),
+			fmt.Sprintf("<td>%d</td>", len(section.rows)),
+			fmt.Sprintf("<td>%s</td>", strings.Join(filters, "<br>")),
+		}
+	}
+	sb.WriteString(w.table([]string{"Table", "Group", "Rows", "Filters"}, summary))
+
+	nullToken := fmt.Sprintf("<td class=\"null\"><em>%s</em></td>", html.EscapeString(reportNullToken(w.NullToken)))
+	for _, section := range w.report.sections {
+		sb.WriteString(fmt.Sprintf("%s<h2>%s</h2>%s<p>%s</p>", xmlIndent(w.Formatted, 2),
+			html.EscapeString(section.table), xmlIndent(w.Formatted, 2), reportRowCount(len(section.rows))))
+		if len(section.rows) == 0 {
+			continue
+		}
+
+		header := make([]string, len(section.columns))
+		for i, column := range section.columns {
+			header[i] = html.EscapeString(column)
+		}
+
+		cells := make([][]string, len(section.rows))
+		for i, row := range section.rows {
+			cells[i] = make([]string, len(section.columns))
+			for j, name := range section.columns {
+				if column := section.cell(row, name); column.Value == nil {
+					cells[i][j] = nullToken
+				} else {
+					cells[i][j] = fmt.Sprintf("<td>%s</td>", html.EscapeString(reportValue(column)))
+				}
+			}
+		}
+
+		sb.WriteString(w.table(header, cells))
+	}
+
+	sb.WriteString(fmt.Sprintf("%s</body>%s</html>", xmlIndent(w.Formatted, 1), xmlIndent(w.Formatted, 0)))
+	if w.Formatted {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (w *HTMLWriter) table(header []string, rows [][]string) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%s<table>%s<thead>%s<tr>", xmlIndent(w.Formatted, 2), xmlIndent(w.Formatted, 3),
+		xmlIndent(w.Formatted, 4)))
+	for _, title := range header {
+		sb.WriteString(fmt.Sprintf("<th>%s</th>", title))
+	}
+
+	sb.WriteString(fmt.Sprintf("</tr>%s</thead>%s<tbody>", xmlIndent(w.Formatted, 3), xmlIndent(w.Formatted, 3)))
+	for _, cells := range rows {
+		sb.WriteString(fmt.Sprintf("%s<tr>%s</tr>", xmlIndent(w.Formatted, 4), strings.Join(cells, "")))
+	}
+
+	sb.WriteString(fmt.Sprintf("%s</tbody>%s</table>", xmlIndent(w.Formatted, 3), xmlIndent(w.Formatted, 2)))
+
+	return sb.String()
+}
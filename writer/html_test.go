@@ -0,0 +1,112 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.HTMLWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestHTMLWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.HTMLWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestHTMLWriteFooterFileWritingError(t *testing.T) {
+	w := writer.HTMLWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-html-writing-error",
+	}
+	require.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestHTMLWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.HTMLWriter{Directory: dir, Name: "test-html-unformatted"}
+
+	require.Nil(t, w.WriteHeader())
+	writeReportTestData(t, &w)
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.html", w.Name)))
+	require.Nil(t, err)
+	content := string(bytes)
+
+	assert.Contains(t, content, "<!DOCTYPE html><html><head><meta charset=\"UTF-8\">"+
+		"<title>test-html-unformatted</title><style>")
+	assert.Contains(t, content, "</style></head><body><h1>test-html-unformatted</h1><h2>Summary</h2>"+
+		"<table><thead><tr><th>Table</th><th>Group</th><th>Rows</th><th>Filters</th></tr></thead><tbody>"+
+		"<tr><td>customers</td><td>1</td><td>1</td><td>id = 34 (from ${customer_id})</td></tr>"+
+		"<tr><td>orders</td><td>2</td><td>2</td>"+
+		"<td>customer_id = 34 (from ${customers.id})<br>status = paid</td></tr>"+
+		"<tr><td>order_items</td><td>3</td><td>0</td><td>order_id in (1, 2) (from ${orders.id[@]})</td></tr>"+
+		"<tr><td>logs</td><td></td><td>1</td><td></td></tr>"+
+		"</tbody></table>")
+	assert.Contains(t, content, "<h2>customers</h2><p>1 row</p>"+
+		"<table><thead><tr><th>id</th><th>name</th><th>photo</th></tr></thead><tbody>"+
+		"<tr><td>34</td><td>Jane | &#34;JJ&#34; *Doe*</td><td>(2 bytes)</td></tr></tbody></table>")
+	assert.Contains(t, content, "<h2>orders</h2><p>2 rows</p>"+
+		"<table><thead><tr><th>id</th><th>created_at</th><th>notes</th></tr></thead><tbody>"+
+		"<tr><td>1</td><td>2023-06-09T14:31:16.478 +0000</td><td class=\"null\"><em>null</em></td></tr>"+
+		"<tr><td>2</td><td class=\"null\"><em>null</em></td><td>a\nb &lt;c&gt;</td></tr></tbody></table>")
+	assert.Contains(t, content, "<h2>order_items</h2><p>0 rows</p><h2>logs</h2>")
+	assert.True(t, strings.HasSuffix(content, "</body></html>"))
+}
+
+func TestHTMLWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.HTMLWriter{Formatted: true, Directory: dir, Name: "test-html-formatted", NullToken: "NULL"}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 1}, {Name: "name", Value: nil}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.html", w.Name)))
+	require.Nil(t, err)
+
+	expected := `    <h2>products</h2>
+    <p>1 row</p>
+    <table>
+      <thead>
+        <tr><th>id</th><th>name</th></tr>
+      </thead>
+      <tbody>
+        <tr><td>1</td><td class="null"><em>NULL</em></td></tr>
+      </tbody>
+    </table>
+  </body>
+</html>
+`
+	assert.Contains(t, string(bytes), expected)
+}
@@ -0,0 +1,140 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type JSONWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	file        *outputFile
+	tables      []string
+	records     map[string][]string
+}
+
+func (w *JSONWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("JSON.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s.json", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("JSON.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.tables = make([]string, 0)
+	w.records = make(map[string][]string)
+
+	return nil
+}
+
+func (w *JSONWriter) WriteFooter() error {
+	content := jsonFileContent(w.Formatted, w.tables, w.records)
+	_, err := w.file.Write(content)
+	if err != nil {
+		log.Printf("JSON.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *JSONWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, exists := w.records[table]; !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	for _, row := range rows {
+		w.records[table] = append(w.records[table], jsonRecord(row))
+	}
+
+	return nil
+}
+
+func jsonFileContent(formatted bool, tables []string, records map[string][]string) []byte {
+	sb := strings.Builder{}
+	sb.WriteRune('{')
+
+	for i, table := range tables {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+
+		sb.WriteString(jsonString(table))
+		sb.WriteString(":[")
+		sb.WriteString(strings.Join(records[table], ","))
+		sb.WriteRune(']')
+	}
+
+	sb.WriteRune('}')
+
+	if !formatted {
+		return []byte(sb.String())
+	}
+
+	var buf bytes.Buffer
+	_ = json.Indent(&buf, []byte(sb.String()), "", "  ")
+	buf.WriteRune('\n')
+
+	return buf.Bytes()
+}
+
+func jsonRecord(row []*reader.DBColumn) string {
+	sb := strings.Builder{}
+	sb.WriteRune('{')
+
+	for i, column := range row {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+
+		sb.WriteString(jsonString(column.Name))
+		sb.WriteRune(':')
+		sb.WriteString(jsonValue(column))
+	}
+
+	sb.WriteRune('}')
+
+	return sb.String()
+}
+
+func jsonValue(column *reader.DBColumn) string {
+	if column.Value == nil {
+		return "null"
+	}
+
+	if value, isBool := booleanValue(column); isBool {
+		return strconv.FormatBool(value)
+	}
+
+	if value, isNumber := numericValue(column); isNumber {
+		return value
+	}
+
+	return jsonString(textValue(column))
+}
+
+func jsonString(value string) string {
+	bts, _ := json.Marshal(value)
+	return string(bts)
+}
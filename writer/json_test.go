@@ -0,0 +1,156 @@
+package writer_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.JSONWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestJSONWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.JSONWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestJSONWriteFooterFileWritingError(t *testing.T) {
+	w := writer.JSONWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "test-error"}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestJSONWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.JSONWriter{
+		Formatted: false,
+		Directory: dir,
+		Name:      "test-write-empty",
+	}
+
+	assert.Nil(t, w.WriteHeader())
+
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.json", w.Name)))
+	assert.Equal(t, "{}", string(bytes))
+}
+
+func TestJSONWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.JSONWriter{
+		Formatted: false,
+		Directory: dir,
+		Name:      "test-write-unformatted",
+	}
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "name", Type: "VARCHAR", Value: "shirt"},
+		{Name: "description", Type: "VARCHAR", Value: "black \"shirt\""},
+		{Name: "price", Type: "NUMBER", Value: "14.50"},
+		{Name: "active", Type: "BOOLEAN", Value: true},
+	}, {
+		{Name: "id", Type: "INTEGER", Value: 2},
+		{Name: "name", Type: "VARCHAR", Value: "pant"},
+		{Name: "description", Type: "VARCHAR"},
+		{Name: "price", Type: "NUMBER", Value: 26.35},
+		{Name: "active", Type: "BOOLEAN", Value: false},
+	}}
+
+	assert.Nil(t, w.Write("products", rows))
+	assert.Nil(t, w.Write("categories", [][]*reader.DBColumn{{{Name: "code", Type: "CHAR", Value: "007"}}}))
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Type: "INTEGER", Value: 3}}}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.json", w.Name)))
+	expected := `{"products":[` +
+		`{"id":1,"name":"shirt","description":"black \"shirt\"","price":14.50,"active":true},` +
+		`{"id":2,"name":"pant","description":null,"price":26.35,"active":false},` +
+		`{"id":3}],"categories":[{"code":"007"}]}`
+
+	assert.Equal(t, expected, string(bytes))
+	assert.True(t, json.Valid(bytes))
+}
+
+func TestJSONWriteBinary(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.JSONWriter{Directory: dir, Name: "test-write-binary"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("documents", [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "content", Type: "BLOB", Value: []byte{1, 2, 3}},
+	}}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.json", w.Name)))
+	assert.Equal(t, `{"documents":[{"id":1,"content":"AQID"}]}`, string(bytes))
+}
+
+func TestJSONWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.JSONWriter{
+		Formatted: true,
+		Directory: dir,
+		Name:      "test-write-formatted",
+	}
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: int64(1)},
+		{Name: "name", Type: "VARCHAR", Value: "shirt"},
+		{Name: "price", Type: "VARCHAR", Value: "14.50"},
+	}}
+
+	assert.Nil(t, w.Write("products", rows))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.json", w.Name)))
+	expected := `{
+  "products": [
+    {
+      "id": 1,
+      "name": "shirt",
+      "price": "14.50"
+    }
+  ]
+}
+`
+
+	assert.Equal(t, expected, string(bytes))
+}
@@ -0,0 +1,176 @@
+package writer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type LiquibaseWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	TableOrder  []string
+	file        *outputFile
+	tables      []string
+	records     map[string][][]*reader.DBColumn
+	blobs       map[string][]byte
+}
+
+const (
+	liquibaseAuthor         = "db-unit-extractor"
+	liquibaseDateLayout     = "2006-01-02"
+	liquibaseDateTimeLayout = "2006-01-02T15:04:05.999999"
+	liquibaseNamespace      = "http://www.liquibase.org/xml/ns/dbchangelog"
+	liquibaseSchemaLocation = liquibaseNamespace + " " + liquibaseNamespace + "/dbchangelog-latest.xsd"
+)
+
+func (w *LiquibaseWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("Liquibase.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s-changelog.xml", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("Liquibase.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.tables = make([]string, 0)
+	w.records = make(map[string][][]*reader.DBColumn)
+	w.blobs = make(map[string][]byte)
+
+	return nil
+}
+
+func (w *LiquibaseWriter) WriteFooter() error {
+	content := []byte(w.changelog())
+	if err := w.writeBlobs(); err != nil {
+		_ = w.file.Close()
+		return err
+	}
+
+	_, err := w.file.Write(content)
+	if err != nil {
+		log.Printf("Liquibase.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *LiquibaseWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, exists := w.records[table]; !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	w.records[table] = append(w.records[table], rows...)
+
+	return nil
+}
+
+func (w *LiquibaseWriter) changelog() string {
+	sb := strings.Builder{}
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>")
+	sb.WriteString(xmlIndent(w.Formatted, 0))
+	sb.WriteString(fmt.Sprintf("<databaseChangeLog xmlns=\"%s\" "+
+		"xmlns:xsi=\"http://www.w3.org/2001/XMLSchema-instance\" xsi:schemaLocation=\"%s\">",
+		liquibaseNamespace, liquibaseSchemaLocation))
+
+	for _, table := range orderedTables(w.TableOrder, w.tables) {
+		sb.WriteString(fmt.Sprintf("%s<changeSet id=\"%s\" author=\"%s\">", xmlIndent(w.Formatted, 1),
+			xmlEscape(table), liquibaseAuthor))
+
+		schema, name := "", table
+		if i := strings.LastIndex(table, "."); i >= 0 {
+			schema, name = table[:i], table[i+1:]
+		}
+
+		for i, row := range w.records[table] {
+			sb.WriteString(xmlIndent(w.Formatted, 2))
+			if schema == "" {
+				sb.WriteString(fmt.Sprintf("<insert tableName=\"%s\">", xmlEscape(name)))
+			} else {
+				sb.WriteString(fmt.Sprintf("<insert schemaName=\"%s\" tableName=\"%s\">", xmlEscape(schema),
+					xmlEscape(name)))
+			}
+
+			for _, column := range row {
+				sb.WriteString(xmlIndent(w.Formatted, 3))
+				sb.WriteString(w.column(table, i, column))
+			}
+
+			sb.WriteString(fmt.Sprintf("%s</insert>", xmlIndent(w.Formatted, 2)))
+		}
+
+		sb.WriteString(fmt.Sprintf("%s</changeSet>", xmlIndent(w.Formatted, 1)))
+	}
+
+	sb.WriteString(fmt.Sprintf("%s</databaseChangeLog>", xmlIndent(w.Formatted, 0)))
+	if w.Formatted {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (w *LiquibaseWriter) column(table string, row int, column *reader.DBColumn) string {
+	name := xmlEscape(column.Name)
+	if column.Value == nil {
+		return fmt.Sprintf("<column name=\"%s\"/>", name)
+	}
+
+	if value, isBool := booleanValue(column); isBool {
+		return fmt.Sprintf("<column name=\"%s\" valueBoolean=\"%t\"/>", name, value)
+	}
+
+	if value, isNumber := numericValue(column); isNumber {
+		return fmt.Sprintf("<column name=\"%s\" valueNumeric=\"%s\"/>", name, value)
+	}
+
+	if value, isDate := dateValue(column); isDate {
+		layout := liquibaseDateTimeLayout
+		if strings.EqualFold(column.Type, "DATE") && isDateOnly(value) {
+			layout = liquibaseDateLayout
+		}
+		return fmt.Sprintf("<column name=\"%s\" valueDate=\"%s\"/>", name, value.Format(layout))
+	}
+
+	if value, isBinary := binaryValue(column); isBinary {
+		path := fmt.Sprintf("%s-blobs/%s-%d-%s.bin", w.Name, table, row+1, column.Name)
+		w.blobs[path] = value
+		return fmt.Sprintf("<column name=\"%s\" valueBlobFile=\"%s\"/>", name, xmlEscape(path))
+	}
+
+	return fmt.Sprintf("<column name=\"%s\" value=\"%s\"/>", name, xmlEscape(textValue(column)))
+}
+
+func (w *LiquibaseWriter) writeBlobs() error {
+	for path, content := range w.blobs {
+		fpath := filepath.Join(w.Directory, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+			log.Printf("Liquibase.WriteFooter\nMake directory %s failed with `%s'\n", filepath.Dir(fpath), err.Error())
+			return err
+		}
+
+		if err := os.WriteFile(fpath, content, os.ModePerm); err != nil {
+			log.Printf("Liquibase.WriteFooter\nWriting to file %s failed: `%s'\n", fpath, err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiquibaseWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.LiquibaseWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestLiquibaseWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.LiquibaseWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestLiquibaseWriteFooterFileWritingError(t *testing.T) {
+	w := writer.LiquibaseWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-liquibase-writing-error",
+	}
+	require.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestLiquibaseWriteFooterBlobWritingError(t *testing.T) {
+	w := writer.LiquibaseWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-liquibase-blob-error",
+	}
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("files", [][]*reader.DBColumn{{{Name: "content", Value: []byte{1}}}}))
+
+	patches := gomonkey.ApplyFunc(os.WriteFile, func(string, []byte, fs.FileMode) error {
+		return fmt.Errorf("blob writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "blob writing error", w.WriteFooter().Error())
+}
+
+func TestLiquibaseWriteFooterBlobDirectoryError(t *testing.T) {
+	w := writer.LiquibaseWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-liquibase-blob-dir-error",
+	}
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("files", [][]*reader.DBColumn{{{Name: "content", Value: []byte{1}}}}))
+
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "mkdir error", w.WriteFooter().Error())
+}
+
+func TestLiquibaseWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.LiquibaseWriter{Directory: dir, Name: "test-liquibase-empty"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-changelog.xml", w.Name)))
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<databaseChangeLog xmlns=\"http://www.liquibase.org/xml/ns/dbchangelog\" "+
+		"xmlns:xsi=\"http://www.w3.org/2001/XMLSchema-instance\" "+
+		"xsi:schemaLocation=\"http://www.liquibase.org/xml/ns/dbchangelog "+
+		"http://www.liquibase.org/xml/ns/dbchangelog/dbchangelog-latest.xsd\"></databaseChangeLog>", string(bytes))
+}
+
+func TestLiquibaseWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.LiquibaseWriter{
+		Directory: dir, Name: "test-liquibase-unformatted", TableOrder: []string{"shop.customers", "orders"},
+	}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "paid", Type: "BOOLEAN", Value: true},
+		{Name: "total", Type: "DECIMAL", Value: "10.50"},
+		{Name: "due", Type: "DATE", Value: time.Date(2023, time.June, 9, 0, 0, 0, 0, time.UTC)},
+		{Name: "created", Type: "TIMESTAMP", Value: "2023-06-09T14:31:16.478 +0000"},
+		{Name: "notes", Type: "VARCHAR", Value: "a & b"},
+		{Name: "receipt", Type: "BLOB", Value: []byte("pdf")},
+		{Name: "removed", Type: "DATE", Value: nil},
+	}}))
+	require.Nil(t, w.Write("shop.customers", [][]*reader.DBColumn{{{Name: "id", Type: "INTEGER", Value: 34}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-changelog.xml", w.Name)))
+	require.Nil(t, err)
+
+	assert.Contains(t, string(bytes), "<changeSet id=\"shop.customers\" author=\"db-unit-extractor\">"+
+		"<insert schemaName=\"shop\" tableName=\"customers\"><column name=\"id\" valueNumeric=\"34\"/></insert>"+
+		"</changeSet>"+
+		"<changeSet id=\"orders\" author=\"db-unit-extractor\"><insert tableName=\"orders\">"+
+		"<column name=\"id\" valueNumeric=\"1\"/>"+
+		"<column name=\"paid\" valueBoolean=\"true\"/>"+
+		"<column name=\"total\" valueNumeric=\"10.50\"/>"+
+		"<column name=\"due\" valueDate=\"2023-06-09\"/>"+
+		"<column name=\"created\" valueDate=\"2023-06-09T14:31:16.478\"/>"+
+		"<column name=\"notes\" value=\"a &amp; b\"/>"+
+		"<column name=\"receipt\" valueBlobFile=\"test-liquibase-unformatted-blobs/orders-1-receipt.bin\"/>"+
+		"<column name=\"removed\"/>"+
+		"</insert></changeSet></databaseChangeLog>")
+
+	bytes, err = os.ReadFile(filepath.Join(dir, "test-liquibase-unformatted-blobs", "orders-1-receipt.bin"))
+	require.Nil(t, err)
+	assert.Equal(t, "pdf", string(bytes))
+}
+
+func TestLiquibaseWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.LiquibaseWriter{Formatted: true, Directory: dir, Name: "test-liquibase-formatted"}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{
+		{{Name: "id", Value: 1}, {Name: "name", Value: "shirt"}},
+		{{Name: "id", Value: 2}, {Name: "name", Value: "pant"}},
+	}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-changelog.xml", w.Name)))
+	require.Nil(t, err)
+
+	expected := `  <changeSet id="products" author="db-unit-extractor">
+    <insert tableName="products">
+      <column name="id" valueNumeric="1"/>
+      <column name="name" value="shirt"/>
+    </insert>
+    <insert tableName="products">
+      <column name="id" valueNumeric="2"/>
+      <column name="name" value="pant"/>
+    </insert>
+  </changeSet>
+</databaseChangeLog>
+`
+	assert.Contains(t, string(bytes), expected)
+}
@@ -0,0 +1,154 @@
+package writer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type MarkdownWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	NullToken   string
+	file        *outputFile
+	report      report
+}
+
+const markdownSeparator = "---"
+
+var markdownReplacer = strings.NewReplacer(
+	"\\", "\\\\", "|", "\\|", "*", "\\*", "_", "\\_", "`", "\\`", "<", "&lt;", "\r\n", "<br>", "\n", "<br>",
+)
+
+func (w *MarkdownWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("Markdown.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s.md", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("Markdown.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.report = report{}
+
+	return nil
+}
+
+func (w *MarkdownWriter) WriteFooter() error {
+	content := []byte(w.document())
+	_, err := w.file.Write(content)
+	if err != nil {
+		log.Printf("Markdown.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *MarkdownWriter) WriteSummary(summary TableSummary) error {
+	w.report.addSummary(summary)
+	return nil
+}
+
+func (w *MarkdownWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	w.report.addRows(table, rows)
+	return nil
+}
+
+func (w *MarkdownWriter) document() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("# %s\n\n## Summary\n\n", markdownEscape(w.Name)))
+
+	summary := make([][]string, len(w.report.sections))
+	for i, section := range w.report.sections {
+		filters := section.filters()
+		for j := range filters {
+			filters[j] = markdownEscape(filters[j])
+		}
+
+		summary[i] = []string{
+			markdownEscape(section.table), section.group(), fmt.Sprint(len(section.rows)), strings.Join(filters, "<br>"),
+		}
+	}
+	sb.WriteString(w.table([]string{"Table", "Group", "Rows", "Filters"}, summary))
+
+	nullToken := fmt.Sprintf("*%s*", markdownEscape(reportNullToken(w.NullToken)))
+	for _, section := range w.report.sections {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n%s\n", markdownEscape(section.table), reportRowCount(len(section.rows))))
+		if len(section.rows) == 0 {
+			continue
+		}
+
+		header := make([]string, len(section.columns))
+		for i, column := range section.columns {
+			header[i] = markdownEscape(column)
+		}
+
+		cells := make([][]string, len(section.rows))
+		for i, row := range section.rows {
+			cells[i] = make([]string, len(section.columns))
+			for j, name := range section.columns {
+				if column := section.cell(row, name); column.Value == nil {
+					cells[i][j] = nullToken
+				} else {
+					cells[i][j] = markdownEscape(reportValue(column))
+				}
+			}
+		}
+
+		sb.WriteString("\n")
+		sb.WriteString(w.table(header, cells))
+	}
+
+	return sb.String()
+}
+
+func (w *MarkdownWriter) table(header []string, rows [][]string) string {
+	widths := make([]int, len(header))
+	separator := make([]string, len(header))
+	for i := range header {
+		widths[i] = len(markdownSeparator)
+		for _, row := range append([][]string{header}, rows...) {
+			if width := utf8.RuneCountInString(row[i]); width > widths[i] {
+				widths[i] = width
+			}
+		}
+
+		separator[i] = markdownSeparator
+		if w.Formatted {
+			separator[i] = strings.Repeat("-", widths[i])
+		}
+	}
+
+	sb := strings.Builder{}
+	for _, cells := range append([][]string{header, separator}, rows...) {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = cell
+			if w.Formatted {
+				padded[i] += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s |\n", strings.Join(padded, " | ")))
+	}
+
+	return sb.String()
+}
+
+func markdownEscape(value string) string {
+	return markdownReplacer.Replace(value)
+}
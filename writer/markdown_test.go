@@ -0,0 +1,182 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.MarkdownWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestMarkdownWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.MarkdownWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestMarkdownWriteFooterFileWritingError(t *testing.T) {
+	w := writer.MarkdownWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-markdown-writing-error",
+	}
+	require.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestMarkdownWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.MarkdownWriter{Directory: dir, Name: "test-markdown-empty"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.md", w.Name)))
+	assert.Equal(t, "# test-markdown-empty\n\n## Summary\n\n"+
+		"| Table | Group | Rows | Filters |\n| --- | --- | --- | --- |\n", string(bytes))
+}
+
+func TestMarkdownWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.MarkdownWriter{Directory: dir, Name: "test-markdown-unformatted"}
+
+	require.Nil(t, w.WriteHeader())
+	writeReportTestData(t, &w)
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.md", w.Name)))
+	require.Nil(t, err)
+
+	expected := `# test-markdown-unformatted
+
+## Summary
+
+| Table | Group | Rows | Filters |
+| --- | --- | --- | --- |
+| customers | 1 | 1 | id = 34 (from ${customer\_id}) |
+| orders | 2 | 2 | customer\_id = 34 (from ${customers.id})<br>status = paid |
+| order\_items | 3 | 0 | order\_id in (1, 2) (from ${orders.id[@]}) |
+| logs |  | 1 |  |
+
+## customers
+
+1 row
+
+| id | name | photo |
+| --- | --- | --- |
+| 34 | Jane \| "JJ" \*Doe\* | (2 bytes) |
+
+## orders
+
+2 rows
+
+| id | created\_at | notes |
+| --- | --- | --- |
+| 1 | 2023-06-09T14:31:16.478 +0000 | *null* |
+| 2 | *null* | a<br>b &lt;c> |
+
+## order\_items
+
+0 rows
+
+## logs
+
+1 row
+
+| message |
+| --- |
+| started |
+`
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestMarkdownWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.MarkdownWriter{Formatted: true, Directory: dir, Name: "test-markdown-formatted", NullToken: "NULL"}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.WriteSummary(writer.TableSummary{Table: "products", Group: 1}))
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{
+		{{Name: "id", Value: 1}, {Name: "name", Value: "shirt"}},
+		{{Name: "id", Value: 20}, {Name: "name", Value: nil}},
+	}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.md", w.Name)))
+	require.Nil(t, err)
+
+	expected := `# test-markdown-formatted
+
+## Summary
+
+| Table    | Group | Rows | Filters |
+| -------- | ----- | ---- | ------- |
+| products | 1     | 2    |         |
+
+## products
+
+2 rows
+
+| id  | name   |
+| --- | ------ |
+| 1   | shirt  |
+| 20  | *NULL* |
+`
+	assert.Equal(t, expected, string(bytes))
+}
+
+func writeReportTestData(t *testing.T, w writer.FileWriter) {
+	sw, isSummaryWriter := w.(writer.SummaryWriter)
+	require.True(t, isSummaryWriter)
+
+	require.Nil(t, sw.WriteSummary(writer.TableSummary{Table: "customers", Group: 1, Filters: []writer.TableFilter{
+		{Name: "id", Expression: "${customer_id}", Value: "34"},
+	}}))
+	require.Nil(t, w.Write("customers", [][]*reader.DBColumn{{
+		{Name: "id", Value: 34}, {Name: "name", Value: "Jane | \"JJ\" *Doe*"},
+		{Name: "photo", Type: "BLOB", Value: []byte{1, 2}},
+	}}))
+	require.Nil(t, sw.WriteSummary(writer.TableSummary{Table: "orders", Group: 2, Filters: []writer.TableFilter{
+		{Name: "customer_id", Expression: "${customers.id}", Value: 34},
+		{Name: "status", Expression: "paid", Value: "paid"},
+	}}))
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{
+		{
+			{Name: "id", Value: 1},
+			{Name: "created_at", Value: time.Date(2023, time.June, 9, 14, 31, 16, 478000000, time.UTC)},
+		},
+		{{Name: "id", Value: 2}, {Name: "notes", Value: "a\nb <c>"}},
+	}))
+	require.Nil(t, sw.WriteSummary(writer.TableSummary{Table: "order_items", Group: 3, Filters: []writer.TableFilter{
+		{Name: "order_id", Expression: "${orders.id[@]}", Value: []interface{}{1, 2}},
+	}}))
+	require.Nil(t, w.Write("order_items", [][]*reader.DBColumn{}))
+	require.Nil(t, w.Write("logs", [][]*reader.DBColumn{{{Name: "message", Value: "started"}}}))
+}
@@ -0,0 +1,80 @@
+package writer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	nameTimestampLayout = "20060102150405"
+	nameRefPrefix       = "ref."
+	nameExtSuffix       = ".{ext}"
+	nameTablePattern    = "{table}"
+)
+
+var (
+	ErrInvalidNameTemplate = errors.New("invalid name template")
+	namePlaceholderRegExp  = regexp.MustCompile(`\{([^{}]*)\}`)
+	nameUnsafeCharsRegExp  = regexp.MustCompile(`[^\w.-]+`)
+	fileExtensions         = map[string]string{
+		"xml": "xml", "xml-full": "xml", "sql": "sql", "sql-cleanup": "sql", "json": "json", "yaml": "yml",
+		"csv": "csv", "xlsx": "xlsx", "liquibase": "xml", "testfixtures": "yml", "go": "go", "markdown": "md",
+		"html": "html",
+	}
+)
+
+func fileName(conf FileConf, table string) (string, error) {
+	template := conf.NameTemplate
+	if template == "" {
+		template = "{schema}"
+	}
+
+	template = strings.TrimSuffix(template, nameExtSuffix)
+	if table != "" && !strings.Contains(template, nameTablePattern) {
+		template += "-" + nameTablePattern
+	}
+
+	var err error
+	name := namePlaceholderRegExp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, resolved := namePlaceholderValue(conf, table, placeholder[1:len(placeholder)-1])
+		if !resolved && err == nil {
+			err = fmt.Errorf("%w '%s': unresolved placeholder %s", ErrInvalidNameTemplate, conf.NameTemplate, placeholder)
+		}
+
+		return value
+	})
+
+	if err == nil && name == "" && conf.NameTemplate != "" {
+		err = fmt.Errorf("%w '%s': empty file name", ErrInvalidNameTemplate, conf.NameTemplate)
+	}
+
+	return name, err
+}
+
+func namePlaceholderValue(conf FileConf, table, key string) (string, bool) {
+	switch {
+	case key == "schema":
+		return conf.Name, true
+	case key == "ext":
+		return fileExtensions[strings.ToLower(conf.Type)], true
+	case key == "timestamp":
+		return conf.Timestamp.Format(nameTimestampLayout), true
+	case key == "table":
+		return fileNameValue(table), table != ""
+	case strings.HasPrefix(strings.ToLower(key), nameRefPrefix):
+		ref := key[len(nameRefPrefix):]
+		for name, value := range conf.References {
+			if strings.EqualFold(name, ref) {
+				return fileNameValue(fmt.Sprint(value)), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func fileNameValue(value string) string {
+	return nameUnsafeCharsRegExp.ReplaceAllString(value, "_")
+}
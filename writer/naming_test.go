@@ -0,0 +1,75 @@
+package writer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameTemplate(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer", "naming")
+	defer os.RemoveAll(dir)
+
+	w, err := writer.NewWriter(writer.FileConf{
+		Type: "sql", Directory: dir, Name: "orders", NameTemplate: "{schema}-{ref.customer_id}-{timestamp}.{ext}",
+		References: map[string]interface{}{"CUSTOMER_ID": "34/5"},
+		Timestamp:  time.Date(2023, time.October, 18, 10, 30, 0, 0, time.UTC),
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}))
+	require.Nil(t, w.WriteFooter())
+
+	_, err = os.Stat(filepath.Join(dir, "orders-34_5-20231018103000.sql"))
+	assert.Nil(t, err)
+}
+
+func TestNameTemplateExtension(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer", "naming-ext")
+	defer os.RemoveAll(dir)
+
+	w, err := writer.NewWriter(writer.FileConf{
+		Type: "markdown", Directory: dir, Name: "orders", NameTemplate: "{ext}-{schema}",
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.WriteFooter())
+
+	_, err = os.Stat(filepath.Join(dir, "md-orders.md"))
+	assert.Nil(t, err)
+}
+
+func TestNameTemplateUnresolvedReference(t *testing.T) {
+	_, err := writer.NewWriter(writer.FileConf{Type: "xml", Name: "orders", NameTemplate: "{schema}-{ref.id}"})
+
+	assert.ErrorIs(t, err, writer.ErrInvalidNameTemplate)
+	assert.Equal(t, "invalid name template '{schema}-{ref.id}': unresolved placeholder {ref.id}", err.Error())
+}
+
+func TestNameTemplateTableWithoutSplit(t *testing.T) {
+	_, err := writer.NewWriter(writer.FileConf{Type: "xml", Name: "orders", NameTemplate: "{table}"})
+
+	assert.Equal(t, "invalid name template '{table}': unresolved placeholder {table}", err.Error())
+}
+
+func TestNameTemplateUnknownPlaceholder(t *testing.T) {
+	_, err := writer.NewWriter(writer.FileConf{Type: "xml", Name: "orders", NameTemplate: "{date}", Split: true})
+
+	assert.Equal(t, "invalid name template '{date}': unresolved placeholder {date}", err.Error())
+}
+
+func TestNameTemplateEmptyName(t *testing.T) {
+	_, err := writer.NewWriter(writer.FileConf{
+		Type: "xml", Name: "orders", NameTemplate: "{ref.id}", References: map[string]interface{}{"id": ""},
+	})
+
+	assert.Equal(t, "invalid name template '{ref.id}': empty file name", err.Error())
+}
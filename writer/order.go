@@ -0,0 +1,118 @@
+package writer
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+func SortRows(rows [][]*reader.DBColumn, columns []string) {
+	if len(rows) == 0 {
+		return
+	}
+
+	indexes := orderIndexes(rows[0], columns)
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, index := range indexes {
+			if c := compareColumns(rows[i][index], rows[j][index]); c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func orderIndexes(row []*reader.DBColumn, columns []string) []int {
+	indexes := make([]int, 0, len(row))
+	for _, name := range columns {
+		for i, column := range row {
+			if strings.EqualFold(column.Name, name) {
+				indexes = append(indexes, i)
+				break
+			}
+		}
+	}
+
+	if len(columns) > 0 {
+		return indexes
+	}
+
+	for i, column := range row {
+		if column.PrimaryKey {
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(indexes) > 0 {
+		return indexes
+	}
+
+	for i := range row {
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
+
+func compareColumns(c1, c2 *reader.DBColumn) int {
+	switch {
+	case c1.Value == nil && c2.Value == nil:
+		return 0
+	case c1.Value == nil:
+		return -1
+	case c2.Value == nil:
+		return 1
+	}
+
+	if n1, n2, isNumber := orderNumbers(c1, c2); isNumber {
+		return n1.Cmp(n2)
+	}
+
+	if t1, isDate := dateValue(c1); isDate {
+		if t2, isDate := dateValue(c2); isDate {
+			return t1.Compare(t2)
+		}
+	}
+
+	if b1, isBool := booleanValue(c1); isBool {
+		if b2, isBool := booleanValue(c2); isBool {
+			return compareBooleans(b1, b2)
+		}
+	}
+
+	if b1, isBinary := c1.Value.([]byte); isBinary {
+		if b2, isBinary := c2.Value.([]byte); isBinary {
+			return bytes.Compare(b1, b2)
+		}
+	}
+
+	return strings.Compare(textValue(c1), textValue(c2))
+}
+
+func orderNumbers(c1, c2 *reader.DBColumn) (*big.Rat, *big.Rat, bool) {
+	t1, isNumber1 := numericValue(c1)
+	t2, isNumber2 := numericValue(c2)
+	if !isNumber1 || !isNumber2 {
+		return nil, nil, false
+	}
+
+	n1, isRat1 := new(big.Rat).SetString(t1)
+	n2, isRat2 := new(big.Rat).SetString(t2)
+
+	return n1, n2, isRat1 && isRat2
+}
+
+func compareBooleans(b1, b2 bool) int {
+	switch {
+	case b1 == b2:
+		return 0
+	case b2:
+		return -1
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,89 @@
+package writer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+)
+
+func orderRows(values ...[]interface{}) [][]*reader.DBColumn {
+	rows := make([][]*reader.DBColumn, len(values))
+	for i, row := range values {
+		rows[i] = []*reader.DBColumn{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true, Value: row[0]},
+			{Name: "name", Type: "VARCHAR", Value: row[1]},
+		}
+	}
+
+	return rows
+}
+
+func orderValues(rows [][]*reader.DBColumn, index int) []interface{} {
+	values := make([]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = row[index].Value
+	}
+
+	return values
+}
+
+func TestSortRowsEmpty(t *testing.T) {
+	rows := [][]*reader.DBColumn{}
+	writer.SortRows(rows, nil)
+
+	assert.Empty(t, rows)
+}
+
+func TestSortRowsPrimaryKey(t *testing.T) {
+	rows := orderRows(
+		[]interface{}{int64(10), "c"}, []interface{}{nil, "d"}, []interface{}{"9", "a"}, []interface{}{2.5, "b"},
+	)
+	writer.SortRows(rows, nil)
+
+	assert.Equal(t, []interface{}{nil, 2.5, "9", int64(10)}, orderValues(rows, 0))
+}
+
+func TestSortRowsOrderBy(t *testing.T) {
+	rows := orderRows(
+		[]interface{}{1, "b"}, []interface{}{2, "a"}, []interface{}{3, "b"}, []interface{}{4, "a"},
+	)
+	writer.SortRows(rows, []string{"NAME"})
+
+	assert.Equal(t, []interface{}{2, 4, 1, 3}, orderValues(rows, 0))
+}
+
+func TestSortRowsWithoutPrimaryKey(t *testing.T) {
+	rows := [][]*reader.DBColumn{
+		{{Name: "active", Value: true}, {Name: "data", Value: []byte{2}}},
+		{{Name: "active", Value: false}, {Name: "data", Value: []byte{3}}},
+		{{Name: "active", Value: true}, {Name: "data", Value: []byte{1}}},
+	}
+	writer.SortRows(rows, nil)
+
+	assert.Equal(t, []interface{}{false, true, true}, orderValues(rows, 0))
+	assert.Equal(t, []interface{}{[]byte{3}, []byte{1}, []byte{2}}, orderValues(rows, 1))
+}
+
+func TestSortRowsDates(t *testing.T) {
+	tm := time.Date(2023, time.October, 18, 10, 30, 0, 0, time.UTC)
+	rows := [][]*reader.DBColumn{
+		{{Name: "created_at", Type: "TIMESTAMP", Value: tm}},
+		{{Name: "created_at", Type: "TIMESTAMP", Value: "2023-10-17"}},
+		{{Name: "created_at", Type: "TIMESTAMP", Value: tm.Add(-time.Hour)}},
+	}
+	writer.SortRows(rows, []string{"created_at"})
+
+	assert.Equal(t, []interface{}{"2023-10-17", tm.Add(-time.Hour), tm}, orderValues(rows, 0))
+}
+
+func TestSortRowsStable(t *testing.T) {
+	rows := orderRows(
+		[]interface{}{1, "b"}, []interface{}{1, "a"}, []interface{}{0, "c"},
+	)
+	writer.SortRows(rows, nil)
+
+	assert.Equal(t, []interface{}{"c", "b", "a"}, orderValues(rows, 1))
+}
@@ -0,0 +1,127 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type report struct {
+	sections []*reportSection
+}
+
+type reportSection struct {
+	table   string
+	summary *TableSummary
+	columns []string
+	rows    [][]*reader.DBColumn
+}
+
+const reportDefaultNullToken = "null"
+
+func (r *report) addSummary(summary TableSummary) {
+	r.sections = append(r.sections, &reportSection{table: summary.Table, summary: &summary})
+}
+
+func (r *report) addRows(table string, rows [][]*reader.DBColumn) {
+	var section *reportSection
+	if size := len(r.sections); size > 0 && r.sections[size-1].table == table {
+		section = r.sections[size-1]
+	} else {
+		section = &reportSection{table: table}
+		r.sections = append(r.sections, section)
+	}
+
+	for _, row := range rows {
+		for _, column := range row {
+			if !section.hasColumn(column.Name) {
+				section.columns = append(section.columns, column.Name)
+			}
+		}
+	}
+
+	section.rows = append(section.rows, rows...)
+}
+
+func (s *reportSection) hasColumn(name string) bool {
+	for _, column := range s.columns {
+		if column == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *reportSection) group() string {
+	if s.summary == nil || s.summary.Group == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(s.summary.Group)
+}
+
+func (s *reportSection) filters() []string {
+	if s.summary == nil {
+		return nil
+	}
+
+	filters := make([]string, len(s.summary.Filters))
+	for i, filter := range s.summary.Filters {
+		if values, multivalued := filter.Value.([]interface{}); multivalued {
+			texts := make([]string, len(values))
+			for j, value := range values {
+				texts[j] = fmt.Sprint(value)
+			}
+			filters[i] = fmt.Sprintf("%s in (%s)", filter.Name, strings.Join(texts, ", "))
+		} else {
+			filters[i] = fmt.Sprintf("%s = %v", filter.Name, filter.Value)
+		}
+
+		if filter.Expression != fmt.Sprint(filter.Value) {
+			filters[i] = fmt.Sprintf("%s (from %s)", filters[i], filter.Expression)
+		}
+	}
+
+	return filters
+}
+
+func (s *reportSection) cell(row []*reader.DBColumn, name string) *reader.DBColumn {
+	for _, column := range row {
+		if column.Name == name {
+			return column
+		}
+	}
+
+	return &reader.DBColumn{Name: name}
+}
+
+func reportValue(column *reader.DBColumn) string {
+	if value, isDate := column.Value.(time.Time); isDate {
+		return value.Format(dateTimeLayout)
+	}
+
+	if value, isBinary := binaryValue(column); isBinary {
+		return fmt.Sprintf("(%d bytes)", len(value))
+	}
+
+	return textValue(column)
+}
+
+func reportRowCount(size int) string {
+	if size == 1 {
+		return "1 row"
+	}
+
+	return fmt.Sprintf("%d rows", size)
+}
+
+func reportNullToken(token string) string {
+	if token == "" {
+		return reportDefaultNullToken
+	}
+
+	return token
+}
@@ -0,0 +1,95 @@
+package writer
+
+import (
+	"log"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type SplitWriter struct {
+	Conf      FileConf
+	writers   map[string]FileWriter
+	tables    []string
+	summaries map[string][]TableSummary
+}
+
+func (w *SplitWriter) WriteHeader() error {
+	w.writers = make(map[string]FileWriter)
+	w.tables = make([]string, 0)
+	w.summaries = make(map[string][]TableSummary)
+
+	return nil
+}
+
+func (w *SplitWriter) WriteFooter() error {
+	var err error
+	for _, table := range w.tables {
+		if e := w.writers[table].WriteFooter(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+func (w *SplitWriter) WriteSummary(summary TableSummary) error {
+	fw, exists := w.writers[summary.Table]
+	if !exists {
+		w.summaries[summary.Table] = append(w.summaries[summary.Table], summary)
+		return nil
+	}
+
+	if sw, isSummaryWriter := fw.(SummaryWriter); isSummaryWriter {
+		return sw.WriteSummary(summary)
+	}
+
+	return nil
+}
+
+func (w *SplitWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	fw, err := w.writer(table)
+	if err != nil {
+		return err
+	}
+
+	return fw.Write(table, rows)
+}
+
+func (w *SplitWriter) writer(table string) (FileWriter, error) {
+	if fw, exists := w.writers[table]; exists {
+		return fw, nil
+	}
+
+	conf := w.Conf
+	name, err := fileName(conf, table)
+	if err != nil {
+		log.Printf("Split.Write\nFile name of table %s not resolved: `%s'\n", table, err.Error())
+		return nil, err
+	}
+
+	conf.Name, conf.NameTemplate, conf.Split = name, "", false
+	fw, err := newFileWriter(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = fw.WriteHeader(); err != nil {
+		return nil, err
+	}
+
+	w.writers[table] = fw
+	w.tables = append(w.tables, table)
+
+	for _, summary := range w.summaries[table] {
+		if err = w.WriteSummary(summary); err != nil {
+			return nil, err
+		}
+	}
+	delete(w.summaries, table)
+
+	return fw, nil
+}
@@ -0,0 +1,157 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriterSplit(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "xml", Split: true})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.SplitWriter{}, w)
+
+	w, err = writer.NewWriter(writer.FileConf{Type: "console", Split: true})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.ConsoleWriter{}, w)
+
+	_, err = writer.NewWriter(writer.FileConf{Type: "pdf", Split: true})
+	assert.ErrorIs(t, err, writer.ErrUnsupportedFileWriter)
+}
+
+func TestSplitWriteHeaderError(t *testing.T) {
+	w := writer.SplitWriter{Conf: writer.FileConf{Type: "sql", Name: "orders"}}
+	require.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	assert.Nil(t, w.WriteSummary(writer.TableSummary{Table: "orders"}))
+	assert.Equal(t, "mkdir error", w.Write("orders", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}).Error())
+}
+
+func TestSplitWriteUnresolvedName(t *testing.T) {
+	w := writer.SplitWriter{Conf: writer.FileConf{Type: "sql", NameTemplate: "{ref.id}-{table}"}}
+	require.Nil(t, w.WriteHeader())
+
+	assert.ErrorIs(t, w.Write("orders", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}), writer.ErrInvalidNameTemplate)
+}
+
+func TestSplitWriteUnsupportedType(t *testing.T) {
+	w := writer.SplitWriter{Conf: writer.FileConf{Type: "pdf", Name: "orders"}}
+	require.Nil(t, w.WriteHeader())
+
+	assert.ErrorIs(t, w.Write("orders", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}), writer.ErrUnsupportedFileWriter)
+}
+
+func TestSplitWriteFooterFileWritingError(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer", "split-error")
+	defer os.RemoveAll(dir)
+
+	w, err := writer.NewWriter(writer.FileConf{Type: "json", Directory: dir, Name: "orders", Split: true})
+	require.Nil(t, err)
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}))
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestSplitWrite(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer", "split")
+	defer os.RemoveAll(dir)
+
+	w, err := writer.NewWriter(writer.FileConf{Type: "sql", Directory: dir, Name: "orders", Split: true})
+	require.Nil(t, err)
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("customers", [][]*reader.DBColumn{{{Name: "id", Value: 34}}}))
+	require.Nil(t, w.Write("shop.orders", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}))
+	require.Nil(t, w.Write("customers", [][]*reader.DBColumn{{{Name: "id", Value: 35}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "orders-customers.sql"))
+	require.Nil(t, err)
+	assert.Equal(t, "insert into customers(id) values(34);insert into customers(id) values(35);", string(bytes))
+
+	bytes, err = os.ReadFile(filepath.Join(dir, "orders-shop.orders.sql"))
+	require.Nil(t, err)
+	assert.Equal(t, "insert into shop.orders(id) values(1);", string(bytes))
+}
+
+func TestSplitWriteNameTemplateAndSummary(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer", "split-template")
+	defer os.RemoveAll(dir)
+
+	w, err := writer.NewWriter(writer.FileConf{
+		Type: "markdown", Directory: dir, Name: "orders", NameTemplate: "{table}_{ref.id}.{ext}", Split: true,
+		References: map[string]interface{}{"id": 34},
+	})
+	require.Nil(t, err)
+
+	sw, isSummaryWriter := w.(writer.SummaryWriter)
+	require.True(t, isSummaryWriter)
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, sw.WriteSummary(writer.TableSummary{Table: "customers", Group: 1}))
+	require.Nil(t, w.Write("customers", [][]*reader.DBColumn{{{Name: "id", Value: 34}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, "customers_34.md"))
+	require.Nil(t, err)
+	assert.Contains(t, string(bytes), "# customers\\_34\n\n## Summary\n\n| Table | Group | Rows | Filters |\n"+
+		"| --- | --- | --- | --- |\n| customers | 1 | 1 |  |\n")
+}
+
+func TestSplitWriteSummaryNotSupported(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer", "split-summary")
+	defer os.RemoveAll(dir)
+
+	w := writer.SplitWriter{Conf: writer.FileConf{Type: "yaml", Directory: dir, Name: "orders"}}
+	require.Nil(t, w.WriteHeader())
+
+	assert.Nil(t, w.WriteSummary(writer.TableSummary{Table: "customers"}))
+	assert.Nil(t, w.Write("customers", [][]*reader.DBColumn{{{Name: "id", Value: 34}}}))
+	assert.Nil(t, w.WriteFooter())
+}
+
+func TestSplitWriteSkipsTablesWithoutRows(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer", "split-empty")
+	defer os.RemoveAll(dir)
+
+	w, err := writer.NewWriter(writer.FileConf{Type: "xml", Directory: dir, Name: "orders", Split: true})
+	require.Nil(t, err)
+
+	sw, isSummaryWriter := w.(writer.SummaryWriter)
+	require.True(t, isSummaryWriter)
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, sw.WriteSummary(writer.TableSummary{Table: "customers", Group: 1}))
+	require.Nil(t, w.Write("customers", [][]*reader.DBColumn{{{Name: "id", Value: 34}}}))
+	require.Nil(t, sw.WriteSummary(writer.TableSummary{Table: "orders", Group: 2}))
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{}))
+	require.Nil(t, w.WriteFooter())
+
+	entries, err := os.ReadDir(dir)
+	require.Nil(t, err)
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	assert.Equal(t, []string{"orders-customers.dtd", "orders-customers.xml"}, names)
+}
@@ -11,10 +11,13 @@ import (
 )
 
 type SQLWriter struct {
-	Formatted bool
-	Directory string
-	Name      string
-	file      *os.File
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	Dialect     string
+	Upsert      bool
+	file        *outputFile
 }
 
 func (w *SQLWriter) WriteHeader() error {
@@ -25,7 +28,7 @@ func (w *SQLWriter) WriteHeader() error {
 	}
 
 	path := filepath.Join(w.Directory, fmt.Sprintf("%s.sql", w.Name))
-	w.file, err = os.Create(path)
+	w.file, err = createFile(path, w.Compression)
 	if err != nil {
 		log.Printf("SQL.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
 		return err
@@ -43,7 +46,7 @@ func (w *SQLWriter) Write(table string, rows [][]*reader.DBColumn) error {
 		return nil
 	}
 
-	content := sqlFileBody(w.Formatted, table, rows)
+	content := sqlFileBody(w.Formatted, w.Upsert, findSQLDialect(w.Dialect), table, rows)
 	_, err := w.file.Write(content)
 	if err != nil {
 		log.Printf("SQL.Write\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
@@ -53,15 +56,45 @@ func (w *SQLWriter) Write(table string, rows [][]*reader.DBColumn) error {
 	return err
 }
 
-func sqlFileBody(formatted bool, table string, rows [][]*reader.DBColumn) []byte {
+func sqlFileBody(formatted, upsert bool, dialect sqlDialect, table string, rows [][]*reader.DBColumn) []byte {
+	batches := [][][]*reader.DBColumn{rows}
+	if !dialect.multiRowInsert {
+		batches = make([][][]*reader.DBColumn, len(rows))
+		for i, row := range rows {
+			batches[i] = [][]*reader.DBColumn{row}
+		}
+	}
+
+	keys := primaryKeyColumns(rows[0])
+	sb := strings.Builder{}
+	for _, batch := range batches {
+		if upsert && len(keys) > 0 {
+			sb.WriteString(dialect.upsert(sqlUpsert{
+				formatted: formatted, dialect: dialect, table: table, rows: batch, keys: keys,
+			}))
+		} else {
+			sb.WriteString(sqlInsert(formatted, dialect, table, batch))
+		}
+
+		if formatted {
+			sb.WriteString(";\n")
+		} else {
+			sb.WriteRune(';')
+		}
+	}
+
+	return []byte(sb.String())
+}
+
+func sqlInsert(formatted bool, dialect sqlDialect, table string, rows [][]*reader.DBColumn) string {
 	if formatted {
-		return formattedSQLRecord(table, rows)
+		return formattedSQLRecord(dialect, table, rows)
 	}
 
-	return unformattedSQLRecord(table, rows)
+	return unformattedSQLRecord(dialect, table, rows)
 }
 
-func formattedSQLRecord(table string, rows [][]*reader.DBColumn) []byte {
+func formattedSQLRecord(dialect sqlDialect, table string, rows [][]*reader.DBColumn) string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("INSERT INTO %s(\n  ", table))
 
@@ -74,27 +107,26 @@ func formattedSQLRecord(table string, rows [][]*reader.DBColumn) []byte {
 	}
 
 	sb.WriteString("\n) VALUES")
-	for _, row := range rows {
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+
 		sb.WriteString("\n  (")
-		for i, column := range row {
-			if i > 0 {
+		for j, column := range row {
+			if j > 0 {
 				sb.WriteString(", ")
 			}
 
-			if column.Value == nil {
-				sb.WriteString("null")
-			} else {
-				sb.WriteString(fmt.Sprintf("'%v'", column.Value))
-			}
+			sb.WriteString(sqlLiteral(column, dialect))
 		}
 		sb.WriteString(")")
 	}
-	sb.WriteString(";\n")
 
-	return []byte(sb.String())
+	return sb.String()
 }
 
-func unformattedSQLRecord(table string, rows [][]*reader.DBColumn) []byte {
+func unformattedSQLRecord(dialect sqlDialect, table string, rows [][]*reader.DBColumn) string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("insert into %s(", table))
 
@@ -107,22 +139,21 @@ func unformattedSQLRecord(table string, rows [][]*reader.DBColumn) []byte {
 	}
 
 	sb.WriteString(") values")
-	for _, row := range rows {
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+
 		sb.WriteRune('(')
-		for i, column := range row {
-			if i > 0 {
+		for j, column := range row {
+			if j > 0 {
 				sb.WriteRune(',')
 			}
 
-			if column.Value == nil {
-				sb.WriteString("null")
-			} else {
-				sb.WriteString(fmt.Sprintf("'%v'", column.Value))
-			}
+			sb.WriteString(sqlLiteral(column, dialect))
 		}
 		sb.WriteRune(')')
 	}
-	sb.WriteRune(';')
 
-	return []byte(sb.String())
+	return sb.String()
 }
@@ -0,0 +1,173 @@
+package writer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type SQLCleanupWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	Dialect     string
+	TableOrder  []string
+	file        *outputFile
+	tables      []string
+	records     map[string][][]*reader.DBColumn
+}
+
+const sqlInListMaxSize = 1000
+
+func (w *SQLCleanupWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("SQLCleanup.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s-cleanup.sql", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("SQLCleanup.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.tables = make([]string, 0)
+	w.records = make(map[string][][]*reader.DBColumn)
+
+	return nil
+}
+
+func (w *SQLCleanupWriter) WriteFooter() error {
+	tables := orderedTables(w.TableOrder, w.tables)
+	dialect := findSQLDialect(w.Dialect)
+
+	sb := strings.Builder{}
+	for i := len(tables) - 1; i >= 0; i-- {
+		sb.WriteString(sqlCleanupBody(w.Formatted, dialect, tables[i], w.records[tables[i]]))
+	}
+
+	content := []byte(sb.String())
+	_, err := w.file.Write(content)
+	if err != nil {
+		log.Printf("SQLCleanup.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *SQLCleanupWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, exists := w.records[table]; !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	w.records[table] = append(w.records[table], rows...)
+
+	return nil
+}
+
+func sqlCleanupBody(formatted bool, dialect sqlDialect, table string, rows [][]*reader.DBColumn) string {
+	keys := primaryKeyColumns(rows[0])
+	sb := strings.Builder{}
+
+	if len(keys) == 1 {
+		for start := 0; start < len(rows); start += sqlInListMaxSize {
+			end := start + sqlInListMaxSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+
+			sb.WriteString(sqlDeleteIn(formatted, dialect, table, keys[0], rows[start:end]))
+		}
+
+		return sb.String()
+	}
+
+	if len(keys) == 0 {
+		keys = comparableColumns(rows[0])
+	}
+
+	if len(keys) == 0 {
+		log.Printf("SQLCleanup.WriteFooter\nTable %s skipped: no primary key nor comparable columns\n", table)
+		return ""
+	}
+
+	for _, row := range rows {
+		sb.WriteString(sqlDeleteRow(formatted, dialect, table, keys, row))
+	}
+
+	return sb.String()
+}
+
+func primaryKeyColumns(row []*reader.DBColumn) []int {
+	keys := make([]int, 0)
+	for i, column := range row {
+		if column.PrimaryKey {
+			keys = append(keys, i)
+		}
+	}
+
+	return keys
+}
+
+func comparableColumns(row []*reader.DBColumn) []int {
+	keys := make([]int, 0)
+	for i, column := range row {
+		if _, isBytes := column.Value.([]byte); !isBytes && !isLOBType(column.Type) {
+			keys = append(keys, i)
+		}
+	}
+
+	return keys
+}
+
+func isLOBType(dbType string) bool {
+	tp := strings.ToUpper(dbType)
+	return isBinaryType(tp) || strings.Contains(tp, "CLOB") || strings.Contains(tp, "TEXT") || tp == "LONG"
+}
+
+func sqlDeleteIn(formatted bool, dialect sqlDialect, table string, key int, rows [][]*reader.DBColumn) string {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = sqlLiteral(row[key], dialect)
+	}
+
+	if formatted {
+		return fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s);\n", table, rows[0][key].Name, strings.Join(values, ", "))
+	}
+
+	return fmt.Sprintf("delete from %s where %s in(%s);", table, rows[0][key].Name, strings.Join(values, ","))
+}
+
+func sqlDeleteRow(formatted bool, dialect sqlDialect, table string, keys []int, row []*reader.DBColumn) string {
+	conditions := make([]string, len(keys))
+	for i, key := range keys {
+		column := row[key]
+		switch {
+		case column.Value == nil && formatted:
+			conditions[i] = fmt.Sprintf("%s IS NULL", column.Name)
+		case column.Value == nil:
+			conditions[i] = fmt.Sprintf("%s is null", column.Name)
+		default:
+			conditions[i] = fmt.Sprintf("%s = %s", column.Name, sqlLiteral(column, dialect))
+		}
+	}
+
+	if formatted {
+		return fmt.Sprintf("DELETE FROM %s WHERE %s;\n", table, strings.Join(conditions, " AND "))
+	}
+
+	return fmt.Sprintf("delete from %s where %s;", table, strings.Join(conditions, " and "))
+}
@@ -0,0 +1,173 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLCleanupWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.SQLCleanupWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestSQLCleanupWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.SQLCleanupWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestSQLCleanupWriteFooterFileWritingError(t *testing.T) {
+	w := writer.SQLCleanupWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-cleanup-writing-error",
+	}
+	require.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestSQLCleanupWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLCleanupWriter{Directory: dir, Name: "test-cleanup-empty"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-cleanup.sql", w.Name)))
+	assert.Empty(t, string(bytes))
+}
+
+func TestSQLCleanupWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLCleanupWriter{Directory: dir, Name: "test-cleanup-unformatted"}
+
+	require.Nil(t, w.WriteHeader())
+	writeCleanupTestData(t, &w)
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-cleanup.sql", w.Name)))
+	require.Nil(t, err)
+
+	expected := "delete from order_notes where note = 'late''s' and removed is null;" +
+		"delete from order_items where order_id = 1 and product_id = 7;" +
+		"delete from order_items where order_id = 2 and product_id = 7;" +
+		"delete from orders where id in(1,2);"
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestSQLCleanupWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLCleanupWriter{Formatted: true, Directory: dir, Name: "test-cleanup-formatted"}
+
+	require.Nil(t, w.WriteHeader())
+	writeCleanupTestData(t, &w)
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-cleanup.sql", w.Name)))
+	require.Nil(t, err)
+
+	expected := `DELETE FROM order_notes WHERE note = 'late''s' AND removed IS NULL;
+DELETE FROM order_items WHERE order_id = 1 AND product_id = 7;
+DELETE FROM order_items WHERE order_id = 2 AND product_id = 7;
+DELETE FROM orders WHERE id IN (1, 2);
+`
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestSQLCleanupWriteTableOrder(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLCleanupWriter{
+		Directory: dir, Name: "test-cleanup-table-order", TableOrder: []string{"customers", "orders"},
+	}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{{{Name: "id", Value: 1, PrimaryKey: true}}}))
+	require.Nil(t, w.Write("customers", [][]*reader.DBColumn{{{Name: "id", Value: "c1", PrimaryKey: true}}}))
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{{{Name: "id", Value: 2, PrimaryKey: true}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-cleanup.sql", w.Name)))
+	require.Nil(t, err)
+
+	assert.Equal(t, "delete from orders where id in(1,2);delete from customers where id in('c1');", string(bytes))
+}
+
+func TestSQLCleanupWriteWithoutPrimaryKeyIgnoresLOBColumns(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLCleanupWriter{Directory: dir, Name: "test-cleanup-lob-columns"}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("documents", [][]*reader.DBColumn{{
+		{Name: "code", Type: "VARCHAR2", Value: "d1"},
+		{Name: "body", Type: "CLOB", Value: "long text"},
+		{Name: "summary", Type: "TEXT", Value: "text"},
+		{Name: "image", Type: "BLOB", Value: "AQI="},
+		{Name: "raw", Type: "", Value: []byte{1, 2}},
+		{Name: "version", Type: "NUMBER", Value: 3},
+	}}))
+	require.Nil(t, w.Write("attachments", [][]*reader.DBColumn{{{Name: "content", Type: "BLOB", Value: "AQI="}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-cleanup.sql", w.Name)))
+	require.Nil(t, err)
+
+	assert.Equal(t, "delete from documents where code = 'd1' and version = 3;", string(bytes))
+}
+
+func TestSQLCleanupWriteInListLimit(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLCleanupWriter{Directory: dir, Name: "test-cleanup-in-list-limit"}
+
+	rows := make([][]*reader.DBColumn, 1001)
+	for i := range rows {
+		rows[i] = []*reader.DBColumn{{Name: "id", Value: i, PrimaryKey: true}}
+	}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("orders", rows))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-cleanup.sql", w.Name)))
+	require.Nil(t, err)
+
+	assert.Contains(t, string(bytes), ",999);delete from orders where id in(1000);")
+}
+
+func writeCleanupTestData(t *testing.T, w writer.FileWriter) {
+	require.Nil(t, w.Write("orders", [][]*reader.DBColumn{
+		{{Name: "id", Value: 1, PrimaryKey: true}, {Name: "status", Value: "open"}},
+		{{Name: "id", Value: 2, PrimaryKey: true}, {Name: "status", Value: "closed"}},
+	}))
+	require.Nil(t, w.Write("order_items", [][]*reader.DBColumn{
+		{{Name: "order_id", Value: 1, PrimaryKey: true}, {Name: "product_id", Value: 7, PrimaryKey: true}},
+		{{Name: "order_id", Value: 2, PrimaryKey: true}, {Name: "product_id", Value: 7, PrimaryKey: true}},
+	}))
+	require.Nil(t, w.Write("order_notes", [][]*reader.DBColumn{
+		{{Name: "note", Value: "late's"}, {Name: "removed", Value: nil}},
+	}))
+}
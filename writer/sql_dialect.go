@@ -0,0 +1,226 @@
+package writer
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type sqlDialect struct {
+	multiRowInsert bool
+	text           func(string) string
+	boolean        func(bool) string
+	date           func(string, time.Time) string
+	binary         func([]byte) string
+	upsert         func(sqlUpsert) string
+}
+
+const (
+	sqlDateLayout        = "2006-01-02"
+	sqlTimestampLayout   = "2006-01-02 15:04:05.999999999"
+	sqlTimestampTZLayout = "2006-01-02 15:04:05.999999999-07:00"
+	defaultSQLDialect    = "ansi"
+)
+
+var sqlDialects = map[string]sqlDialect{
+	defaultSQLDialect: {
+		multiRowInsert: true, text: sqlText, boolean: sqlBoolean, date: ansiDate, binary: sqlHexBinary,
+		upsert: valuesMergeUpsert,
+	},
+	"oracle": {
+		multiRowInsert: false, text: sqlText, boolean: sqlNumericBoolean, date: oracleDate,
+		binary: func(bts []byte) string { return fmt.Sprintf("HEXTORAW('%X')", bts) },
+		upsert: selectMergeUpsert,
+	},
+	"postgres": {
+		multiRowInsert: true, text: sqlText, boolean: sqlBoolean, date: postgresDate,
+		binary: func(bts []byte) string { return fmt.Sprintf("'\\x%X'::bytea", bts) },
+		upsert: onConflictUpsert,
+	},
+	"mysql": {
+		multiRowInsert: true, text: mysqlText, boolean: sqlBoolean, date: mysqlDate, binary: sqlHexBinary,
+		upsert: onDuplicateKeyUpsert,
+	},
+	"sqlite3": {
+		multiRowInsert: true, text: sqlText, boolean: sqlNumericBoolean, date: sqliteDate, binary: sqlHexBinary,
+		upsert: onConflictUpsert,
+	},
+	"sqlserver": {
+		multiRowInsert: true, text: sqlText, boolean: sqlNumericBoolean, date: sqlserverDate,
+		binary: func(bts []byte) string { return fmt.Sprintf("0x%X", bts) },
+		upsert: valuesMergeUpsert,
+	},
+}
+
+func SQLDialects() []string {
+	dialects := make([]string, 0, len(sqlDialects))
+	for name := range sqlDialects {
+		dialects = append(dialects, name)
+	}
+	sort.Strings(dialects)
+
+	return dialects
+}
+
+func findSQLDialect(name string) sqlDialect {
+	if dialect, found := sqlDialects[strings.ToLower(name)]; found {
+		return dialect
+	}
+
+	return sqlDialects[defaultSQLDialect]
+}
+
+func sqlLiteral(column *reader.DBColumn, dialect sqlDialect) string {
+	if column.Value == nil {
+		return "null"
+	}
+
+	if value, isBool := booleanValue(column); isBool {
+		return dialect.boolean(value)
+	}
+
+	if value, isNumber := numericValue(column); isNumber {
+		return value
+	}
+
+	if value, isDate := dateValue(column); isDate {
+		return dialect.date(strings.ToUpper(column.Type), value)
+	}
+
+	if value, isBinary := binaryValue(column); isBinary {
+		return dialect.binary(value)
+	}
+
+	return dialect.text(textValue(column))
+}
+
+func binaryValue(column *reader.DBColumn) ([]byte, bool) {
+	switch value := column.Value.(type) {
+	case []byte:
+		return value, true
+	case string:
+		if !isBinaryType(column.Type) {
+			return nil, false
+		}
+
+		bts, err := base64.StdEncoding.DecodeString(value)
+		return bts, err == nil
+	default:
+		return nil, false
+	}
+}
+
+func isBinaryType(dbType string) bool {
+	tp := strings.ToUpper(dbType)
+	for _, binaryType := range []string{"BINARY", "BLOB", "BYTEA", "RAW", "IMAGE"} {
+		if strings.Contains(tp, binaryType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDateOnly(tm time.Time) bool {
+	return tm.Hour() == 0 && tm.Minute() == 0 && tm.Second() == 0 && tm.Nanosecond() == 0
+}
+
+func hasTimeZone(dbType string) bool {
+	return strings.Contains(dbType, "TIME ZONE") || strings.HasSuffix(dbType, "TZ") || dbType == "DATETIMEOFFSET"
+}
+
+func sqlText(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
+
+func mysqlText(value string) string {
+	return sqlText(strings.ReplaceAll(value, "\\", "\\\\"))
+}
+
+func sqlBoolean(value bool) string {
+	if value {
+		return "TRUE"
+	}
+
+	return "FALSE"
+}
+
+func sqlNumericBoolean(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
+
+func sqlHexBinary(bts []byte) string {
+	return fmt.Sprintf("X'%s'", strings.ToUpper(hex.EncodeToString(bts)))
+}
+
+func ansiDate(dbType string, tm time.Time) string {
+	if dbType == "DATE" && isDateOnly(tm) {
+		return fmt.Sprintf("DATE '%s'", tm.Format(sqlDateLayout))
+	}
+
+	if hasTimeZone(dbType) {
+		return fmt.Sprintf("TIMESTAMP '%s'", tm.Format(sqlTimestampTZLayout))
+	}
+
+	return fmt.Sprintf("TIMESTAMP '%s'", tm.Format(sqlTimestampLayout))
+}
+
+func oracleDate(dbType string, tm time.Time) string {
+	switch {
+	case dbType == "DATE":
+		return fmt.Sprintf("TO_DATE('%s', 'YYYY-MM-DD HH24:MI:SS')", tm.Format("2006-01-02 15:04:05"))
+	case hasTimeZone(dbType):
+		return fmt.Sprintf("TO_TIMESTAMP_TZ('%s', 'YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM')",
+			tm.Format("2006-01-02 15:04:05.000000000 -07:00"))
+	default:
+		return fmt.Sprintf("TO_TIMESTAMP('%s', 'YYYY-MM-DD HH24:MI:SS.FF9')",
+			tm.Format("2006-01-02 15:04:05.000000000"))
+	}
+}
+
+func postgresDate(dbType string, tm time.Time) string {
+	switch {
+	case dbType == "DATE":
+		return fmt.Sprintf("'%s'::date", tm.Format(sqlDateLayout))
+	case hasTimeZone(dbType):
+		return fmt.Sprintf("'%s'::timestamptz", tm.Format(sqlTimestampTZLayout))
+	default:
+		return fmt.Sprintf("'%s'::timestamp", tm.Format(sqlTimestampLayout))
+	}
+}
+
+func mysqlDate(dbType string, tm time.Time) string {
+	if dbType == "DATE" {
+		return fmt.Sprintf("DATE '%s'", tm.Format(sqlDateLayout))
+	}
+
+	return fmt.Sprintf("TIMESTAMP '%s'", tm.Format(sqlTimestampLayout))
+}
+
+func sqliteDate(dbType string, tm time.Time) string {
+	if dbType == "DATE" && isDateOnly(tm) {
+		return sqlText(tm.Format(sqlDateLayout))
+	}
+
+	return sqlText(tm.Format(sqlTimestampLayout))
+}
+
+func sqlserverDate(dbType string, tm time.Time) string {
+	switch {
+	case dbType == "DATE":
+		return fmt.Sprintf("CAST('%s' AS DATE)", tm.Format(sqlDateLayout))
+	case hasTimeZone(dbType):
+		return fmt.Sprintf("CAST('%s' AS DATETIMEOFFSET)", tm.Format("2006-01-02T15:04:05.9999999-07:00"))
+	default:
+		return fmt.Sprintf("CAST('%s' AS DATETIME2)", tm.Format("2006-01-02T15:04:05.9999999"))
+	}
+}
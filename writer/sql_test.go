@@ -6,11 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/aureliano/db-unit-extractor/reader"
 	"github.com/aureliano/db-unit-extractor/writer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSQLWriteHeaderMkdirAllError(t *testing.T) {
@@ -91,7 +93,7 @@ func TestSQLWriteUnformatted(t *testing.T) {
 	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
 	actual := string(bytes)
 	expected := "insert into products(id,name,description,price) " +
-		"values('1','shirt','black shirt','14.5')('2','pant',null,'26.35');"
+		"values(1,'shirt','black shirt',14.5),(2,'pant',null,26.35);"
 
 	assert.Equal(t, expected, actual)
 }
@@ -125,7 +127,189 @@ func TestSQLWriteFormatted(t *testing.T) {
 	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
 	actual := string(bytes)
 	expected := "INSERT INTO products(\n  id, name, description, price\n) " +
-		"VALUES\n  ('1', 'shirt', 'black shirt', '14.5')\n  ('2', 'pant', null, '26.35');\n"
+		"VALUES\n  (1, 'shirt', 'black shirt', 14.5),\n  (2, 'pant', null, 26.35);\n"
 
 	assert.Equal(t, expected, actual)
 }
+
+func sqlDialectRows() [][]*reader.DBColumn {
+	return [][]*reader.DBColumn{{
+		{Name: "id", Type: "NUMBER", Value: "1"},
+		{Name: "name", Type: "VARCHAR2", Value: "O'Brien \\ Co"},
+		{Name: "active", Type: "BOOLEAN", Value: true},
+		{Name: "birth", Type: "DATE", Value: "1990-01-31"},
+		{Name: "created", Type: "TIMESTAMP", Value: "2023-06-09T14:31:16.478 -0300"},
+		{Name: "updated", Type: "TIMESTAMP WITH TIME ZONE", Value: time.Date(2023, 6, 9, 14, 31, 16, 0, time.UTC)},
+		{Name: "photo", Type: "BLOB", Value: "3q2+7w=="},
+		{Name: "raw", Type: "VARBINARY", Value: []byte{0x0A, 0xFF}},
+		{Name: "code", Type: "CHAR", Value: "007"},
+	}, {
+		{Name: "id", Type: "NUMBER", Value: int64(2)},
+		{Name: "name", Type: "VARCHAR2"},
+		{Name: "active", Type: "BOOLEAN", Value: false},
+		{Name: "birth", Type: "DATE"},
+		{Name: "created", Type: "TIMESTAMP"},
+		{Name: "updated", Type: "TIMESTAMP WITH TIME ZONE"},
+		{Name: "photo", Type: "BLOB"},
+		{Name: "raw", Type: "VARBINARY"},
+		{Name: "code", Type: "CHAR", Value: "not base64"},
+	}}
+}
+
+func TestSQLWriteDialects(t *testing.T) {
+	header := "insert into people(id,name,active,birth,created,updated,photo,raw,code) values"
+	expected := map[string]string{
+		"": header + "(1,'O''Brien \\ Co',TRUE,DATE '1990-01-31',TIMESTAMP '2023-06-09 14:31:16.478'," +
+			"TIMESTAMP '2023-06-09 14:31:16+00:00',X'DEADBEEF',X'0AFF','007')," +
+			"(2,null,FALSE,null,null,null,null,null,'not base64');",
+		"oracle": header + "(1,'O''Brien \\ Co',1,TO_DATE('1990-01-31 00:00:00', 'YYYY-MM-DD HH24:MI:SS')," +
+			"TO_TIMESTAMP('2023-06-09 14:31:16.478000000', 'YYYY-MM-DD HH24:MI:SS.FF9')," +
+			"TO_TIMESTAMP_TZ('2023-06-09 14:31:16.000000000 +00:00', 'YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM')," +
+			"HEXTORAW('DEADBEEF'),HEXTORAW('0AFF'),'007');" +
+			header + "(2,null,0,null,null,null,null,null,'not base64');",
+		"postgres": header + "(1,'O''Brien \\ Co',TRUE,'1990-01-31'::date,'2023-06-09 14:31:16.478'::timestamp," +
+			"'2023-06-09 14:31:16+00:00'::timestamptz,'\\xDEADBEEF'::bytea,'\\x0AFF'::bytea,'007')," +
+			"(2,null,FALSE,null,null,null,null,null,'not base64');",
+		"mysql": header + "(1,'O''Brien \\\\ Co',TRUE,DATE '1990-01-31',TIMESTAMP '2023-06-09 14:31:16.478'," +
+			"TIMESTAMP '2023-06-09 14:31:16',X'DEADBEEF',X'0AFF','007')," +
+			"(2,null,FALSE,null,null,null,null,null,'not base64');",
+		"sqlite3": header + "(1,'O''Brien \\ Co',1,'1990-01-31','2023-06-09 14:31:16.478'," +
+			"'2023-06-09 14:31:16',X'DEADBEEF',X'0AFF','007')," +
+			"(2,null,0,null,null,null,null,null,'not base64');",
+		"sqlserver": header + "(1,'O''Brien \\ Co',1,CAST('1990-01-31' AS DATE)," +
+			"CAST('2023-06-09T14:31:16.478' AS DATETIME2),CAST('2023-06-09T14:31:16+00:00' AS DATETIMEOFFSET)," +
+			"0xDEADBEEF,0x0AFF,'007'),(2,null,0,null,null,null,null,null,'not base64');",
+	}
+
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	for dialect, sql := range expected {
+		w := writer.SQLWriter{Directory: dir, Name: "test-write-dialect", Dialect: dialect}
+
+		require.Nil(t, w.WriteHeader())
+		require.Nil(t, w.Write("people", sqlDialectRows()))
+		require.Nil(t, w.WriteFooter())
+
+		bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
+		assert.Equal(t, sql, string(bytes), "dialect: %s", dialect)
+	}
+}
+
+func TestSQLWriteFormattedOracle(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLWriter{Formatted: true, Directory: dir, Name: "test-write-oracle", Dialect: "ORACLE"}
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{
+		{{Name: "id", Type: "NUMBER", Value: 1}, {Name: "name", Type: "VARCHAR2", Value: "shirt"}},
+		{{Name: "id", Type: "NUMBER", Value: 2}, {Name: "name", Type: "VARCHAR2", Value: "pant"}},
+	}
+	assert.Nil(t, w.Write("products", rows))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
+	expected := "INSERT INTO products(\n  id, name\n) VALUES\n  (1, 'shirt');\n" +
+		"INSERT INTO products(\n  id, name\n) VALUES\n  (2, 'pant');\n"
+
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestSQLWriteUpsert(t *testing.T) {
+	insert := "insert into products(id,name) values(1,'shirt'),(2,'pant')"
+	expected := map[string]string{
+		"": "merge into products tgt using (values (1,'shirt'),(2,'pant')) src (id,name) on (tgt.id = src.id) " +
+			"when matched then update set tgt.name = src.name " +
+			"when not matched then insert (id,name) values (src.id,src.name);",
+		"oracle": "merge into products tgt using (select 1 id,'shirt' name from dual) src on (tgt.id = src.id) " +
+			"when matched then update set tgt.name = src.name " +
+			"when not matched then insert (id,name) values (src.id,src.name);" +
+			"merge into products tgt using (select 2 id,'pant' name from dual) src on (tgt.id = src.id) " +
+			"when matched then update set tgt.name = src.name " +
+			"when not matched then insert (id,name) values (src.id,src.name);",
+		"postgres": insert + " on conflict (id) do update set name = excluded.name;",
+		"sqlite3":  insert + " on conflict (id) do update set name = excluded.name;",
+		"mysql":    insert + " on duplicate key update name = values(name);",
+		"sqlserver": "merge into products tgt using (values (1,'shirt'),(2,'pant')) src (id,name) " +
+			"on (tgt.id = src.id) when matched then update set tgt.name = src.name " +
+			"when not matched then insert (id,name) values (src.id,src.name);",
+	}
+
+	rows := [][]*reader.DBColumn{
+		{{Name: "id", Value: 1, PrimaryKey: true}, {Name: "name", Value: "shirt"}},
+		{{Name: "id", Value: 2, PrimaryKey: true}, {Name: "name", Value: "pant"}},
+	}
+
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	for dialect, sql := range expected {
+		w := writer.SQLWriter{Directory: dir, Name: "test-write-upsert", Dialect: dialect, Upsert: true}
+
+		require.Nil(t, w.WriteHeader())
+		require.Nil(t, w.Write("products", rows))
+		require.Nil(t, w.WriteFooter())
+
+		bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
+		assert.Equal(t, sql, string(bytes), "dialect: %s", dialect)
+	}
+}
+
+func TestSQLWriteUpsertKeyOnly(t *testing.T) {
+	expected := map[string]string{
+		"postgres": "insert into tags(id) values(1) on conflict (id) do nothing;",
+		"mysql":    "insert into tags(id) values(1) on duplicate key update id = values(id);",
+		"sqlserver": "merge into tags tgt using (values (1)) src (id) on (tgt.id = src.id) " +
+			"when not matched then insert (id) values (src.id);",
+	}
+
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	for dialect, sql := range expected {
+		w := writer.SQLWriter{Directory: dir, Name: "test-write-upsert-key-only", Dialect: dialect, Upsert: true}
+
+		require.Nil(t, w.WriteHeader())
+		require.Nil(t, w.Write("tags", [][]*reader.DBColumn{{{Name: "id", Value: 1, PrimaryKey: true}}}))
+		require.Nil(t, w.WriteFooter())
+
+		bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
+		assert.Equal(t, sql, string(bytes), "dialect: %s", dialect)
+	}
+}
+
+func TestSQLWriteUpsertWithoutPrimaryKey(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLWriter{Directory: dir, Name: "test-write-upsert-no-pk", Dialect: "postgres", Upsert: true}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("logs", [][]*reader.DBColumn{{{Name: "msg", Value: "hi"}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
+	assert.Equal(t, "insert into logs(msg) values('hi');", string(bytes))
+}
+
+func TestSQLWriteUpsertFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.SQLWriter{
+		Formatted: true, Directory: dir, Name: "test-write-upsert-formatted", Dialect: "sqlserver", Upsert: true,
+	}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("order_items", [][]*reader.DBColumn{{
+		{Name: "order_id", Value: 1, PrimaryKey: true},
+		{Name: "product_id", Value: 7, PrimaryKey: true},
+		{Name: "amount", Value: 3},
+	}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.sql", w.Name)))
+	expected := "MERGE INTO order_items tgt USING (VALUES (1, 7, 3)) src (order_id, product_id, amount)\n" +
+		"ON (tgt.order_id = src.order_id AND tgt.product_id = src.product_id)\n" +
+		"WHEN MATCHED THEN UPDATE SET tgt.amount = src.amount\n" +
+		"WHEN NOT MATCHED THEN INSERT (order_id, product_id, amount) " +
+		"VALUES (src.order_id, src.product_id, src.amount);\n"
+
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestSQLDialects(t *testing.T) {
+	assert.Equal(t, []string{"ansi", "mysql", "oracle", "postgres", "sqlite3", "sqlserver"}, writer.SQLDialects())
+}
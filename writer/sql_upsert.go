@@ -0,0 +1,189 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type sqlUpsert struct {
+	formatted bool
+	dialect   sqlDialect
+	table     string
+	rows      [][]*reader.DBColumn
+	keys      []int
+}
+
+const (
+	sqlUpsertTarget = "tgt"
+	sqlUpsertSource = "src"
+)
+
+func (u sqlUpsert) keyword(kw string) string {
+	if u.formatted {
+		return kw
+	}
+
+	return strings.ToLower(kw)
+}
+
+func (u sqlUpsert) separator() string {
+	if u.formatted {
+		return "\n"
+	}
+
+	return " "
+}
+
+func (u sqlUpsert) join(values []string) string {
+	if u.formatted {
+		return strings.Join(values, ", ")
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (u sqlUpsert) columns() []string {
+	names := make([]string, len(u.rows[0]))
+	for i, column := range u.rows[0] {
+		names[i] = column.Name
+	}
+
+	return names
+}
+
+func (u sqlUpsert) keyColumns() []string {
+	names := make([]string, len(u.keys))
+	for i, key := range u.keys {
+		names[i] = u.rows[0][key].Name
+	}
+
+	return names
+}
+
+func (u sqlUpsert) updatableColumns() []string {
+	isKey := make(map[int]bool)
+	for _, key := range u.keys {
+		isKey[key] = true
+	}
+
+	names := make([]string, 0)
+	for i, column := range u.rows[0] {
+		if !isKey[i] {
+			names = append(names, column.Name)
+		}
+	}
+
+	return names
+}
+
+func (u sqlUpsert) literals(row []*reader.DBColumn) []string {
+	values := make([]string, len(row))
+	for i, column := range row {
+		values[i] = sqlLiteral(column, u.dialect)
+	}
+
+	return values
+}
+
+func onConflictUpsert(u sqlUpsert) string {
+	sb := strings.Builder{}
+	sb.WriteString(sqlInsert(u.formatted, u.dialect, u.table, u.rows))
+	sb.WriteString(u.separator())
+	sb.WriteString(fmt.Sprintf("%s (%s) ", u.keyword("ON CONFLICT"), u.join(u.keyColumns())))
+
+	columns := u.updatableColumns()
+	if len(columns) == 0 {
+		sb.WriteString(u.keyword("DO NOTHING"))
+		return sb.String()
+	}
+
+	assignments := make([]string, len(columns))
+	for i, name := range columns {
+		assignments[i] = fmt.Sprintf("%s = %s.%s", name, u.keyword("EXCLUDED"), name)
+	}
+	sb.WriteString(fmt.Sprintf("%s %s", u.keyword("DO UPDATE SET"), u.join(assignments)))
+
+	return sb.String()
+}
+
+func onDuplicateKeyUpsert(u sqlUpsert) string {
+	columns := u.updatableColumns()
+	if len(columns) == 0 {
+		columns = u.keyColumns()[:1]
+	}
+
+	assignments := make([]string, len(columns))
+	for i, name := range columns {
+		assignments[i] = fmt.Sprintf("%s = %s(%s)", name, u.keyword("VALUES"), name)
+	}
+
+	return fmt.Sprintf("%s%s%s %s", sqlInsert(u.formatted, u.dialect, u.table, u.rows), u.separator(),
+		u.keyword("ON DUPLICATE KEY UPDATE"), u.join(assignments))
+}
+
+func valuesMergeUpsert(u sqlUpsert) string {
+	tuples := make([]string, len(u.rows))
+	for i, row := range u.rows {
+		tuples[i] = fmt.Sprintf("(%s)", u.join(u.literals(row)))
+	}
+
+	source := fmt.Sprintf("(%s %s) %s (%s)", u.keyword("VALUES"), u.join(tuples), sqlUpsertSource,
+		u.join(u.columns()))
+
+	return mergeUpsert(u, source)
+}
+
+func selectMergeUpsert(u sqlUpsert) string {
+	columns := u.columns()
+	selects := make([]string, len(u.rows))
+	for i, row := range u.rows {
+		values := u.literals(row)
+		for j := range values {
+			values[j] = fmt.Sprintf("%s %s", values[j], columns[j])
+		}
+		selects[i] = fmt.Sprintf("%s %s %s %s", u.keyword("SELECT"), u.join(values), u.keyword("FROM"),
+			u.keyword("DUAL"))
+	}
+
+	source := fmt.Sprintf("(%s) %s", strings.Join(selects, fmt.Sprintf(" %s ", u.keyword("UNION ALL"))),
+		sqlUpsertSource)
+
+	return mergeUpsert(u, source)
+}
+
+func mergeUpsert(u sqlUpsert, source string) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%s %s %s %s %s", u.keyword("MERGE INTO"), u.table, sqlUpsertTarget,
+		u.keyword("USING"), source))
+
+	keys := u.keyColumns()
+	conditions := make([]string, len(keys))
+	for i, name := range keys {
+		conditions[i] = fmt.Sprintf("%s.%s = %s.%s", sqlUpsertTarget, name, sqlUpsertSource, name)
+	}
+	sb.WriteString(u.separator())
+	sb.WriteString(fmt.Sprintf("%s (%s)", u.keyword("ON"),
+		strings.Join(conditions, fmt.Sprintf(" %s ", u.keyword("AND")))))
+
+	if columns := u.updatableColumns(); len(columns) > 0 {
+		assignments := make([]string, len(columns))
+		for i, name := range columns {
+			assignments[i] = fmt.Sprintf("%s.%s = %s.%s", sqlUpsertTarget, name, sqlUpsertSource, name)
+		}
+		sb.WriteString(u.separator())
+		sb.WriteString(fmt.Sprintf("%s %s", u.keyword("WHEN MATCHED THEN UPDATE SET"), u.join(assignments)))
+	}
+
+	columns := u.columns()
+	values := make([]string, len(columns))
+	for i, name := range columns {
+		values[i] = fmt.Sprintf("%s.%s", sqlUpsertSource, name)
+	}
+	sb.WriteString(u.separator())
+	sb.WriteString(fmt.Sprintf("%s (%s) %s (%s)", u.keyword("WHEN NOT MATCHED THEN INSERT"), u.join(columns),
+		u.keyword("VALUES"), u.join(values)))
+
+	return sb.String()
+}
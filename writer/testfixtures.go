@@ -0,0 +1,100 @@
+package writer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type TestfixturesWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	files       map[string]*outputFile
+	tables      []string
+}
+
+func (w *TestfixturesWriter) WriteHeader() error {
+	dir := w.directory()
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		log.Printf("Testfixtures.WriteHeader\nMake directory %s failed with `%s'\n", dir, err.Error())
+		return err
+	}
+
+	w.files = make(map[string]*outputFile)
+	w.tables = make([]string, 0)
+
+	return nil
+}
+
+func (w *TestfixturesWriter) WriteFooter() error {
+	var err error
+	for _, table := range w.tables {
+		if e := w.files[table].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+func (w *TestfixturesWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	file, exists := w.files[table]
+	if !exists {
+		path := filepath.Join(w.directory(), fmt.Sprintf("%s.yml", table))
+		var err error
+		file, err = createFile(path, w.Compression)
+		if err != nil {
+			log.Printf("Testfixtures.Write\nFile %s not created: `%s'\n", path, err.Error())
+			return err
+		}
+
+		w.files[table] = file
+		w.tables = append(w.tables, table)
+	}
+
+	content := testfixturesFileBody(w.Formatted, rows)
+	_, err := file.Write(content)
+	if err != nil {
+		log.Printf("Testfixtures.Write\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+	}
+
+	return err
+}
+
+func (w *TestfixturesWriter) directory() string {
+	return filepath.Join(w.Directory, fmt.Sprintf("%s-fixtures", w.Name))
+}
+
+func testfixturesFileBody(formatted bool, rows [][]*reader.DBColumn) []byte {
+	sb := strings.Builder{}
+	for _, row := range rows {
+		sb.WriteString(yamlRecord(formatted, "", row, testfixturesValue))
+	}
+
+	return []byte(sb.String())
+}
+
+func testfixturesValue(column *reader.DBColumn) string {
+	if value, isDate := dateValue(column); isDate {
+		return value.Format(time.RFC3339Nano)
+	}
+
+	if value, isBinary := binaryValue(column); isBinary {
+		return fmt.Sprintf("!!binary %s", base64.StdEncoding.EncodeToString(value))
+	}
+
+	return yamlValue(column)
+}
@@ -0,0 +1,125 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTestfixturesWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.TestfixturesWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestTestfixturesWriteFileCreationError(t *testing.T) {
+	w := writer.TestfixturesWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file creation error", w.Write("products", [][]*reader.DBColumn{{}}).Error())
+}
+
+func TestTestfixturesWriteBodyFileWritingError(t *testing.T) {
+	w := writer.TestfixturesWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "fixtures-error",
+	}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.Write("products", [][]*reader.DBColumn{{}}).Error())
+}
+
+func TestTestfixturesWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.TestfixturesWriter{Directory: dir, Name: "test-write-fixtures-empty"}
+	defer os.RemoveAll(filepath.Join(dir, "test-write-fixtures-empty-fixtures"))
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+	assert.Nil(t, w.WriteFooter())
+
+	entries, err := os.ReadDir(filepath.Join(dir, "test-write-fixtures-empty-fixtures"))
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestTestfixturesWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.TestfixturesWriter{Formatted: true, Directory: dir, Name: "test-write-fixtures"}
+	defer os.RemoveAll(filepath.Join(dir, "test-write-fixtures-fixtures"))
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "name", Type: "VARCHAR", Value: "yes"},
+		{Name: "active", Type: "BOOLEAN", Value: true},
+		{Name: "created_at", Type: "TIMESTAMP", Value: "2023-06-09T14:31:16.478 -0300"},
+		{Name: "photo", Type: "BLOB", Value: []byte{0xDE, 0xAD}},
+		{Name: "description", Type: "VARCHAR"},
+	}}))
+	assert.Nil(t, w.Write("categories", [][]*reader.DBColumn{{{Name: "id", Value: 7}}}))
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 2}}}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, "test-write-fixtures-fixtures", "products.yml"))
+	expected := `- id: 1
+  name: "yes"
+  active: true
+  created_at: 2023-06-09T14:31:16.478-03:00
+  photo: !!binary 3q0=
+  description: null
+- id: 2
+`
+	assert.Equal(t, expected, string(bytes))
+
+	var fixtures []map[string]interface{}
+	require.Nil(t, yaml.Unmarshal(bytes, &fixtures))
+	assert.Equal(t, "yes", fixtures[0]["name"])
+	assert.Equal(t, "\xDE\xAD", fixtures[0]["photo"])
+	tm, err := time.Parse(time.RFC3339, fmt.Sprint(fixtures[0]["created_at"]))
+	require.Nil(t, err)
+	assert.Equal(t, time.Date(2023, time.June, 9, 17, 31, 16, 478000000, time.UTC), tm.UTC())
+
+	bytes, _ = os.ReadFile(filepath.Join(dir, "test-write-fixtures-fixtures", "categories.yml"))
+	assert.Equal(t, "- id: 7\n", string(bytes))
+}
+
+func TestTestfixturesWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.TestfixturesWriter{Directory: dir, Name: "test-write-fixtures-unformatted"}
+	defer os.RemoveAll(filepath.Join(dir, "test-write-fixtures-unformatted-fixtures"))
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{
+		{{Name: "id", Value: 1}, {Name: "name", Value: "shirt"}},
+		{{Name: "id", Value: 2}, {Name: "name", Value: "pant, blue"}},
+	}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, "test-write-fixtures-unformatted-fixtures", "products.yml"))
+	assert.Equal(t, "- {id: 1, name: shirt}\n- {id: 2, name: \"pant, blue\"}\n", string(bytes))
+}
@@ -0,0 +1,81 @@
+package writer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02T15:04:05.999 -0700"
+)
+
+var (
+	numberRegExp = regexp.MustCompile(`^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$`)
+	numericTypes = map[string]bool{
+		"INT": true, "INTEGER": true, "INT2": true, "INT4": true, "INT8": true, "SMALLINT": true, "TINYINT": true,
+		"MEDIUMINT": true, "BIGINT": true, "SERIAL": true, "BIGSERIAL": true, "NUMBER": true, "NUMERIC": true,
+		"DECIMAL": true, "DEC": true, "FLOAT": true, "FLOAT4": true, "FLOAT8": true, "DOUBLE": true,
+		"DOUBLE PRECISION": true, "REAL": true, "MONEY": true, "SMALLMONEY": true, "BINARY_FLOAT": true,
+		"BINARY_DOUBLE": true,
+	}
+)
+
+func isNumericType(dbType string) bool {
+	return numericTypes[strings.ToUpper(dbType)]
+}
+
+func numericValue(column *reader.DBColumn) (string, bool) {
+	switch value := column.Value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		text := fmt.Sprint(value)
+		return text, numberRegExp.MatchString(text)
+	case string:
+		return value, isNumericType(column.Type) && numberRegExp.MatchString(value)
+	default:
+		return "", false
+	}
+}
+
+func booleanValue(column *reader.DBColumn) (bool, bool) {
+	value, isBool := column.Value.(bool)
+	return value, isBool
+}
+
+func textValue(column *reader.DBColumn) string {
+	if bts, isBytes := column.Value.([]byte); isBytes {
+		return base64.StdEncoding.EncodeToString(bts)
+	}
+
+	return fmt.Sprintf("%v", column.Value)
+}
+
+func isDateType(dbType string) bool {
+	tp := strings.ToUpper(dbType)
+	return tp == "DATE" || tp == "SMALLDATETIME" || strings.HasPrefix(tp, "DATETIME") ||
+		strings.HasPrefix(tp, "TIMESTAMP")
+}
+
+func dateValue(column *reader.DBColumn) (time.Time, bool) {
+	switch value := column.Value.(type) {
+	case time.Time:
+		return value, true
+	case string:
+		if !isDateType(column.Type) {
+			return time.Time{}, false
+		}
+
+		for _, layout := range []string{dateTimeLayout, dateLayout} {
+			if tm, err := time.Parse(layout, value); err == nil {
+				return tm, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -4,15 +4,38 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aureliano/db-unit-extractor/reader"
 )
 
 type FileConf struct {
-	Type      string
-	Formatted bool
-	Directory string
-	Name      string
+	Type         string
+	Formatted    bool
+	Directory    string
+	Name         string
+	NullToken    string
+	TableOrder   []string
+	Dialect      string
+	Upsert       bool
+	Package      string
+	Compression  string
+	NameTemplate string
+	References   map[string]interface{}
+	Timestamp    time.Time
+	Split        bool
+}
+
+type TableFilter struct {
+	Name       string
+	Expression string
+	Value      interface{}
+}
+
+type TableSummary struct {
+	Table   string
+	Group   int
+	Filters []TableFilter
 }
 
 var ErrUnsupportedFileWriter = errors.New("unsupported file type")
@@ -23,19 +46,130 @@ type FileWriter interface {
 	Write(table string, rows [][]*reader.DBColumn) error
 }
 
+type SummaryWriter interface {
+	WriteSummary(summary TableSummary) error
+}
+
 func NewWriter(conf FileConf) (FileWriter, error) {
+	if !supportedCompression(conf.Compression) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, conf.Compression)
+	}
+
+	if conf.Timestamp.IsZero() {
+		conf.Timestamp = time.Now()
+	}
+
+	if conf.Split && !strings.EqualFold(conf.Type, "console") {
+		if _, err := fileName(conf, conf.Type); err != nil {
+			return nil, err
+		}
+
+		if _, err := newFileWriter(conf); err != nil {
+			return nil, err
+		}
+
+		return &SplitWriter{Conf: conf}, nil
+	}
+
+	name, err := fileName(conf, "")
+	if err != nil {
+		return nil, err
+	}
+	conf.Name = name
+
+	return newFileWriter(conf)
+}
+
+func newFileWriter(conf FileConf) (FileWriter, error) {
 	switch {
 	case strings.EqualFold(conf.Type, "console"):
 		return &ConsoleWriter{}, nil
 	case strings.EqualFold(conf.Type, "xml"):
-		return &XMLWriter{Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name}, nil
+		return &XMLWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, TableOrder: conf.TableOrder,
+			NullToken: conf.NullToken, Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "xml-full"):
+		return &XMLFullWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, TableOrder: conf.TableOrder,
+			Compression: conf.Compression,
+		}, nil
 	case strings.EqualFold(conf.Type, "sql"):
-		return &SQLWriter{Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name}, nil
+		return &SQLWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, Dialect: conf.Dialect,
+			Upsert: conf.Upsert, Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "sql-cleanup"):
+		return &SQLCleanupWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, Dialect: conf.Dialect,
+			TableOrder: conf.TableOrder, Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "json"):
+		return &JSONWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "yaml"):
+		return &YAMLWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "csv"):
+		return &CSVWriter{
+			Directory: conf.Directory, Name: conf.Name, NullToken: conf.NullToken, TableOrder: conf.TableOrder,
+			Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "xlsx"):
+		return &XLSXWriter{Directory: conf.Directory, Name: conf.Name}, nil
+	case strings.EqualFold(conf.Type, "liquibase"):
+		return &LiquibaseWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, TableOrder: conf.TableOrder,
+			Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "testfixtures"):
+		return &TestfixturesWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "go"):
+		return &GoWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, Package: conf.Package,
+			TableOrder: conf.TableOrder, Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "markdown"):
+		return &MarkdownWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, NullToken: conf.NullToken,
+			Compression: conf.Compression,
+		}, nil
+	case strings.EqualFold(conf.Type, "html"):
+		return &HTMLWriter{
+			Formatted: conf.Formatted, Directory: conf.Directory, Name: conf.Name, NullToken: conf.NullToken,
+			Compression: conf.Compression,
+		}, nil
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFileWriter, conf.Type)
 	}
 }
 
 func SupportedTypes() []string {
-	return []string{"console", "xml", "sql"}
+	return []string{
+		"console", "xml", "xml-full", "sql", "sql-cleanup", "json", "yaml", "csv", "xlsx", "liquibase", "testfixtures",
+		"go", "markdown", "html",
+	}
+}
+
+func orderedTables(order, tables []string) []string {
+	written := make(map[string]bool)
+	for _, table := range tables {
+		written[table] = true
+	}
+
+	ordered := make([]string, 0, len(tables))
+	for _, names := range [][]string{order, tables} {
+		for _, table := range names {
+			if written[table] {
+				ordered = append(ordered, table)
+				written[table] = false
+			}
+		}
+	}
+
+	return ordered
 }
@@ -30,10 +30,87 @@ func TestNewWriterSQL(t *testing.T) {
 	assert.IsType(t, &writer.SQLWriter{}, w)
 }
 
+func TestNewWriterJSON(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "json"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.JSONWriter{}, w)
+}
+
+func TestNewWriterYAML(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "yaml"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.YAMLWriter{}, w)
+}
+
+func TestNewWriterCSV(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "csv", NullToken: "<NULL>", TableOrder: []string{"t1", "t2"}})
+	assert.Nil(t, err)
+	assert.Equal(t, &writer.CSVWriter{NullToken: "<NULL>", TableOrder: []string{"t1", "t2"}}, w)
+}
+
+func TestNewWriterXLSX(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "xlsx"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.XLSXWriter{}, w)
+}
+
+func TestNewWriterSQLCleanup(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "sql-cleanup"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.SQLCleanupWriter{}, w)
+}
+
+func TestNewWriterXMLFull(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "xml-full"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.XMLFullWriter{}, w)
+}
+
+func TestNewWriterLiquibase(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "liquibase"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.LiquibaseWriter{}, w)
+}
+
+func TestNewWriterTestfixtures(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "testfixtures"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.TestfixturesWriter{}, w)
+}
+
+func TestNewWriterGo(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "go"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.GoWriter{}, w)
+}
+
+func TestNewWriterMarkdown(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "markdown"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.MarkdownWriter{}, w)
+}
+
+func TestNewWriterHTML(t *testing.T) {
+	w, err := writer.NewWriter(writer.FileConf{Type: "html"})
+	assert.Nil(t, err)
+	assert.IsType(t, &writer.HTMLWriter{}, w)
+}
+
 func TestSupportedTypes(t *testing.T) {
 	types := writer.SupportedTypes()
-	assert.Len(t, types, 3)
+	assert.Len(t, types, 14)
 	assert.Equal(t, "console", types[0])
 	assert.Equal(t, "xml", types[1])
-	assert.Equal(t, "sql", types[2])
+	assert.Equal(t, "xml-full", types[2])
+	assert.Equal(t, "sql", types[3])
+	assert.Equal(t, "sql-cleanup", types[4])
+	assert.Equal(t, "json", types[5])
+	assert.Equal(t, "yaml", types[6])
+	assert.Equal(t, "csv", types[7])
+	assert.Equal(t, "xlsx", types[8])
+	assert.Equal(t, "liquibase", types[9])
+	assert.Equal(t, "testfixtures", types[10])
+	assert.Equal(t, "go", types[11])
+	assert.Equal(t, "markdown", types[12])
+	assert.Equal(t, "html", types[13])
 }
@@ -0,0 +1,341 @@
+package writer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type XLSXWriter struct {
+	Directory string
+	Name      string
+	file      *os.File
+	tables    []string
+	records   map[string][][]*reader.DBColumn
+}
+
+const (
+	xlsxSheetNameMaxLength   = 31
+	xlsxSheetNameFirstSuffix = 2
+	xlsxMaxSignificantDigits = 15
+	xlsxLetters              = 26
+	xlsxDateStyle            = 1
+	xlsxDateTimeStyle        = 2
+	xlsxSecondsPerDay        = 24 * 60 * 60
+	xlsxXMLHeader            = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	xlsxMainNamespace        = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNamespace         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPackageRelNS         = "http://schemas.openxmlformats.org/package/2006/relationships"
+)
+
+var (
+	xlsxEpoch              = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	xlsxSheetNameRegExp    = regexp.MustCompile(`[\[\]:*?/\\]`)
+	xlsxStaticPackageFiles = map[string]string{
+		"_rels/.rels": xlsxXMLHeader + `<Relationships xmlns="` + xlsxPackageRelNS + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxRelNamespace + `/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+		"xl/styles.xml": xlsxXMLHeader + `<styleSheet xmlns="` + xlsxMainNamespace + `">` +
+			`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/>` +
+			`<numFmt numFmtId="165" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+			`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill>` +
+			`<fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+			`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+			`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+			`</styleSheet>`,
+	}
+)
+
+func (w *XLSXWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("XLSX.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s.xlsx", w.Name))
+	w.file, err = os.Create(path)
+	if err != nil {
+		log.Printf("XLSX.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.tables = make([]string, 0)
+	w.records = make(map[string][][]*reader.DBColumn)
+
+	return nil
+}
+
+func (w *XLSXWriter) WriteFooter() error {
+	content, err := xlsxWorkbook(w.tables, w.records)
+	if err == nil {
+		_, err = w.file.Write(content)
+	}
+
+	if err != nil {
+		log.Printf("XLSX.WriteFooter\nWriting to file failed: `%s'\n", err.Error())
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *XLSXWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, exists := w.records[table]; !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	w.records[table] = append(w.records[table], rows...)
+
+	return nil
+}
+
+func xlsxWorkbook(tables []string, records map[string][][]*reader.DBColumn) ([]byte, error) {
+	sheets := xlsxSheetNames(tables)
+	files := make(map[string]string)
+	for name, content := range xlsxStaticPackageFiles {
+		files[name] = content
+	}
+
+	files["[Content_Types].xml"] = xlsxContentTypes(len(sheets))
+	files["xl/workbook.xml"] = xlsxWorkbookContent(sheets)
+	files["xl/_rels/workbook.xml.rels"] = xlsxWorkbookRelationships(len(sheets))
+
+	for i := range sheets {
+		var rows [][]*reader.DBColumn
+		if i < len(tables) {
+			rows = records[tables[i]]
+		}
+		files[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)] = xlsxSheet(rows)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range xlsxPackageFileNames(len(sheets)) {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = fw.Write([]byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func xlsxPackageFileNames(sheets int) []string {
+	names := []string{
+		"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+	}
+
+	for i := 1; i <= sheets; i++ {
+		names = append(names, fmt.Sprintf("xl/worksheets/sheet%d.xml", i))
+	}
+
+	return names
+}
+
+func xlsxSheetNames(tables []string) []string {
+	if len(tables) == 0 {
+		return []string{"Sheet1"}
+	}
+
+	names := make([]string, len(tables))
+	used := make(map[string]bool)
+	for i, table := range tables {
+		base := xlsxSheetNameRegExp.ReplaceAllString(table, "_")
+		name := truncateRunes(base, xlsxSheetNameMaxLength)
+		for n := xlsxSheetNameFirstSuffix; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf("_%d", n)
+			name = truncateRunes(base, xlsxSheetNameMaxLength-len(suffix)) + suffix
+		}
+
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+
+	return names
+}
+
+func truncateRunes(value string, size int) string {
+	runes := []rune(value)
+	if len(runes) > size {
+		return string(runes[:size])
+	}
+
+	return value
+}
+
+func xlsxContentTypes(sheets int) string {
+	sb := strings.Builder{}
+	sb.WriteString(xlsxXMLHeader)
+	sb.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	sb.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	sb.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/` +
+		`vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/` +
+		`vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	for i := 1; i <= sheets; i++ {
+		sb.WriteString(fmt.Sprintf(`<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/`+
+			`vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i))
+	}
+
+	sb.WriteString(`</Types>`)
+
+	return sb.String()
+}
+
+func xlsxWorkbookContent(sheets []string) string {
+	sb := strings.Builder{}
+	sb.WriteString(xlsxXMLHeader)
+	sb.WriteString(fmt.Sprintf(`<workbook xmlns="%s" xmlns:r="%s"><sheets>`, xlsxMainNamespace, xlsxRelNamespace))
+
+	for i, sheet := range sheets {
+		sb.WriteString(fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet), i+1, i+1))
+	}
+
+	sb.WriteString(`</sheets></workbook>`)
+
+	return sb.String()
+}
+
+func xlsxWorkbookRelationships(sheets int) string {
+	sb := strings.Builder{}
+	sb.WriteString(xlsxXMLHeader)
+	sb.WriteString(fmt.Sprintf(`<Relationships xmlns="%s">`, xlsxPackageRelNS))
+
+	for i := 1; i <= sheets; i++ {
+		sb.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`,
+			i, xlsxRelNamespace, i))
+	}
+
+	sb.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`,
+		sheets+1, xlsxRelNamespace))
+	sb.WriteString(`</Relationships>`)
+
+	return sb.String()
+}
+
+func xlsxSheet(rows [][]*reader.DBColumn) string {
+	sb := strings.Builder{}
+	sb.WriteString(xlsxXMLHeader)
+	sb.WriteString(fmt.Sprintf(`<worksheet xmlns="%s"><sheetData>`, xlsxMainNamespace))
+
+	if len(rows) > 0 {
+		sb.WriteString(`<row r="1">`)
+		for i, column := range rows[0] {
+			sb.WriteString(xlsxTextCell(xlsxCellReference(i, 1), column.Name))
+		}
+		sb.WriteString(`</row>`)
+	}
+
+	for i, row := range rows {
+		sb.WriteString(fmt.Sprintf(`<row r="%d">`, i+2))
+		for j, column := range row {
+			sb.WriteString(xlsxCell(xlsxCellReference(j, i+2), column))
+		}
+		sb.WriteString(`</row>`)
+	}
+
+	sb.WriteString(`</sheetData></worksheet>`)
+
+	return sb.String()
+}
+
+func xlsxCell(ref string, column *reader.DBColumn) string {
+	if column.Value == nil {
+		return ""
+	}
+
+	if value, isBool := booleanValue(column); isBool {
+		flag := 0
+		if value {
+			flag = 1
+		}
+		return fmt.Sprintf(`<c r="%s" t="b"><v>%d</v></c>`, ref, flag)
+	}
+
+	if value, isNumber := numericValue(column); isNumber {
+		if !xlsxNumberFits(column, value) {
+			return xlsxTextCell(ref, value)
+		}
+		return fmt.Sprintf(`<c r="%s"><v>%s</v></c>`, ref, value)
+	}
+
+	if value, isDate := dateValue(column); isDate {
+		style := xlsxDateTimeStyle
+		if value.Hour() == 0 && value.Minute() == 0 && value.Second() == 0 {
+			style = xlsxDateStyle
+		}
+		return fmt.Sprintf(`<c r="%s" s="%d"><v>%s</v></c>`, ref, style, xlsxSerialDate(value))
+	}
+
+	return xlsxTextCell(ref, textValue(column))
+}
+
+func xlsxNumberFits(column *reader.DBColumn, value string) bool {
+	switch column.Value.(type) {
+	case float32, float64:
+		return true
+	}
+
+	digits := strings.TrimLeft(value, "+-")
+	if i := strings.IndexAny(digits, "eE"); i >= 0 {
+		digits = digits[:i]
+	}
+
+	if strings.Contains(digits, ".") {
+		digits = strings.TrimRight(digits, "0")
+	}
+
+	digits = strings.TrimLeft(strings.Replace(digits, ".", "", 1), "0")
+
+	return len(digits) <= xlsxMaxSignificantDigits
+}
+
+func xlsxTextCell(ref, value string) string {
+	return fmt.Sprintf(`<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(value))
+}
+
+func xlsxSerialDate(tm time.Time) string {
+	wall := time.Date(tm.Year(), tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(),
+		time.UTC)
+	days := wall.Sub(xlsxEpoch).Seconds() / xlsxSecondsPerDay
+
+	return fmt.Sprint(days)
+}
+
+func xlsxCellReference(column, row int) string {
+	name := ""
+	for column >= 0 {
+		name = string(rune('A'+column%xlsxLetters)) + name
+		column = column/xlsxLetters - 1
+	}
+
+	return fmt.Sprintf("%s%d", name, row)
+}
@@ -0,0 +1,208 @@
+package writer_test
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readXLSXEntries(t *testing.T, path string) map[string]string {
+	zr, err := zip.OpenReader(path)
+	require.Nil(t, err)
+	defer zr.Close()
+
+	entries := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.Nil(t, err)
+
+		bytes, err := io.ReadAll(rc)
+		require.Nil(t, err)
+		_ = rc.Close()
+
+		decoder := xml.NewDecoder(strings.NewReader(string(bytes)))
+		for {
+			_, err = decoder.Token()
+			if err != nil {
+				break
+			}
+		}
+		require.ErrorIs(t, err, io.EOF, "malformed xml in %s", f.Name)
+
+		entries[f.Name] = string(bytes)
+	}
+
+	return entries
+}
+
+func TestXLSXWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.XLSXWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestXLSXWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.XLSXWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestXLSXWriteFooterFileWritingError(t *testing.T) {
+	w := writer.XLSXWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "test-error"}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestXLSXWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XLSXWriter{Directory: dir, Name: "test-write-xlsx-empty"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+	assert.Nil(t, w.WriteFooter())
+
+	entries := readXLSXEntries(t, filepath.Join(dir, fmt.Sprintf("%s.xlsx", w.Name)))
+	assert.Contains(t, entries["xl/workbook.xml"], `<sheet name="Sheet1" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, entries["xl/worksheets/sheet1.xml"], "<sheetData></sheetData>")
+}
+
+func TestXLSXWrite(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XLSXWriter{Directory: dir, Name: "test-write-xlsx"}
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "name", Type: "VARCHAR", Value: "shirt & <tie>"},
+		{Name: "price", Type: "NUMBER", Value: "14.50"},
+		{Name: "created_at", Type: "TIMESTAMP", Value: "2023-06-09T12:00:00 -0300"},
+		{Name: "birth_date", Type: "DATE", Value: time.Date(1990, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{Name: "active", Type: "BOOLEAN", Value: true},
+		{Name: "notes", Type: "VARCHAR"},
+	}}
+
+	assert.Nil(t, w.Write("products", rows))
+	assert.Nil(t, w.Write("sales.categories", [][]*reader.DBColumn{{{Name: "code", Type: "CHAR", Value: "007"}}}))
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Type: "INTEGER", Value: 2}}}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	entries := readXLSXEntries(t, filepath.Join(dir, fmt.Sprintf("%s.xlsx", w.Name)))
+	assert.Len(t, entries, 7)
+	assert.Contains(t, entries["xl/workbook.xml"], `<sheet name="products" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, entries["xl/workbook.xml"], `<sheet name="sales.categories" sheetId="2" r:id="rId2"/>`)
+
+	sheet := entries["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2"><v>1</v></c>`)
+	assert.Contains(t, sheet, `<t xml:space="preserve">shirt &amp; &lt;tie&gt;</t>`)
+	assert.Contains(t, sheet, `<c r="C2"><v>14.50</v></c>`)
+	assert.Contains(t, sheet, `<c r="D2" s="2"><v>45086.5</v></c>`)
+	assert.Contains(t, sheet, `<c r="E2" s="1"><v>32904</v></c>`)
+	assert.Contains(t, sheet, `<c r="F2" t="b"><v>1</v></c>`)
+	assert.NotContains(t, sheet, `r="G2"`)
+	assert.Contains(t, sheet, `<row r="3"><c r="A3"><v>2</v></c></row>`)
+
+	assert.Contains(t, entries["xl/worksheets/sheet2.xml"], `<t xml:space="preserve">007</t>`)
+}
+
+func TestXLSXWriteHighPrecisionNumbers(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XLSXWriter{Directory: dir, Name: "test-write-xlsx-precision"}
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{{
+		{Name: "a", Type: "BIGINT", Value: int64(9007199254740993)},
+		{Name: "b", Type: "NUMBER", Value: "12345678901234567890"},
+		{Name: "c", Type: "DECIMAL", Value: "-0.001234567890123450"},
+		{Name: "d", Type: "DECIMAL", Value: "0.12345678901234567"},
+		{Name: "e", Type: "BIGINT", Value: int64(123456789012345)},
+		{Name: "f", Type: "FLOAT", Value: 0.1234567890123456789},
+	}}
+	assert.Nil(t, w.Write("numbers", rows))
+
+	assert.Nil(t, w.WriteFooter())
+
+	sheet := readXLSXEntries(t, filepath.Join(dir, fmt.Sprintf("%s.xlsx", w.Name)))["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">9007199254740993</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">12345678901234567890</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C2"><v>-0.001234567890123450</v></c>`)
+	assert.Contains(t, sheet, `<c r="D2" t="inlineStr"><is><t xml:space="preserve">0.12345678901234567</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="E2"><v>123456789012345</v></c>`)
+	assert.Contains(t, sheet, `<c r="F2"><v>0.12345678901234568</v></c>`)
+}
+
+func TestXLSXWriteSheetNames(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XLSXWriter{Directory: dir, Name: "test-write-xlsx-sheet-names"}
+
+	assert.Nil(t, w.WriteHeader())
+
+	row := [][]*reader.DBColumn{{{Name: "id", Type: "INTEGER", Value: 1}}}
+	for _, table := range []string{
+		"a_table_with_a_really_long_name_one", "a_table_with_a_really_long_name_two", "sales[1]", "sales:1]",
+		"SALES_1_", "ação_com_um_nome_muito_comprido_ção",
+	} {
+		assert.Nil(t, w.Write(table, row))
+	}
+
+	assert.Nil(t, w.WriteFooter())
+
+	workbook := readXLSXEntries(t, filepath.Join(dir, fmt.Sprintf("%s.xlsx", w.Name)))["xl/workbook.xml"]
+	assert.Contains(t, workbook, `<sheet name="a_table_with_a_really_long_name" sheetId="1"`)
+	assert.Contains(t, workbook, `<sheet name="a_table_with_a_really_long_na_2" sheetId="2"`)
+	assert.Contains(t, workbook, `<sheet name="sales_1_" sheetId="3"`)
+	assert.Contains(t, workbook, `<sheet name="sales_1__2" sheetId="4"`)
+	assert.Contains(t, workbook, `<sheet name="SALES_1__3" sheetId="5"`)
+	assert.Contains(t, workbook, `<sheet name="ação_com_um_nome_muito_comprido" sheetId="6"`)
+}
+
+func TestXLSXWriteManyColumns(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XLSXWriter{Directory: dir, Name: "test-write-xlsx-columns"}
+
+	assert.Nil(t, w.WriteHeader())
+
+	row := make([]*reader.DBColumn, 28)
+	for i := range row {
+		row[i] = &reader.DBColumn{Name: fmt.Sprintf("c%d", i), Type: "INTEGER", Value: i}
+	}
+	assert.Nil(t, w.Write("a_table_with_a_really_long_name_to_be_truncated", [][]*reader.DBColumn{row}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	entries := readXLSXEntries(t, filepath.Join(dir, fmt.Sprintf("%s.xlsx", w.Name)))
+	assert.Contains(t, entries["xl/workbook.xml"], `<sheet name="a_table_with_a_really_long_name"`)
+	assert.Contains(t, entries["xl/worksheets/sheet1.xml"], `<c r="Z2"><v>25</v></c>`)
+	assert.Contains(t, entries["xl/worksheets/sheet1.xml"], `<c r="AB2"><v>27</v></c>`)
+}
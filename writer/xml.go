@@ -1,6 +1,8 @@
 package writer
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
 	"log"
 	"os"
@@ -11,10 +13,15 @@ import (
 )
 
 type XMLWriter struct {
-	Formatted bool
-	Directory string
-	Name      string
-	file      *os.File
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	TableOrder  []string
+	NullToken   string
+	file        *outputFile
+	tables      []string
+	columns     map[string][]reader.DBColumn
 }
 
 func (w *XMLWriter) WriteHeader() error {
@@ -25,13 +32,13 @@ func (w *XMLWriter) WriteHeader() error {
 	}
 
 	path := filepath.Join(w.Directory, fmt.Sprintf("%s.xml", w.Name))
-	w.file, err = os.Create(path)
+	w.file, err = createFile(path, w.Compression)
 	if err != nil {
 		log.Printf("XML.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
 		return err
 	}
 
-	content := xmlFileHeader(w.Formatted)
+	content := xmlFileHeader(w.Formatted, fmt.Sprintf("%s.dtd", w.Name))
 	_, err = w.file.Write(content)
 	if err != nil {
 		log.Printf("XML.WriteHeader\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
@@ -42,6 +49,14 @@ func (w *XMLWriter) WriteHeader() error {
 }
 
 func (w *XMLWriter) WriteFooter() error {
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s.dtd", w.Name))
+	dtd := xmlDTD(orderedTables(w.TableOrder, w.tables), w.columns)
+	if err := os.WriteFile(path, dtd, os.ModePerm); err != nil {
+		log.Printf("XML.WriteFooter\nWriting to file %s failed: `%s'\nContent: %s\n", path, err.Error(), dtd)
+		_ = w.file.Close()
+		return err
+	}
+
 	content := xmlFileFooter()
 	_, err := w.file.Write(content)
 	if err != nil {
@@ -58,7 +73,9 @@ func (w *XMLWriter) Write(table string, rows [][]*reader.DBColumn) error {
 		return nil
 	}
 
-	content := xmlFileBody(w.Formatted, table, rows)
+	w.addColumns(table, rows[0])
+
+	content := xmlFileBody(w.Formatted, w.NullToken, table, rows)
 	_, err := w.file.Write(content)
 	if err != nil {
 		log.Printf("XML.Write\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
@@ -68,35 +85,98 @@ func (w *XMLWriter) Write(table string, rows [][]*reader.DBColumn) error {
 	return err
 }
 
-func xmlFileHeader(formatted bool) []byte {
+func (w *XMLWriter) addColumns(table string, row []*reader.DBColumn) {
+	if w.columns == nil {
+		w.columns = make(map[string][]reader.DBColumn)
+	}
+
+	columns, exists := w.columns[table]
+	if !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	for _, column := range row {
+		found := false
+		for i := range columns {
+			if columns[i].Name == column.Name {
+				columns[i].Nullable = columns[i].Nullable || column.Nullable
+				found = true
+			}
+		}
+
+		if !found {
+			columns = append(columns, reader.DBColumn{Name: column.Name, Nullable: column.Nullable})
+		}
+	}
+
+	w.columns[table] = columns
+}
+
+func xmlFileHeader(formatted bool, dtd string) []byte {
+	doctype := ""
+	if dtd != "" {
+		doctype = fmt.Sprintf("<!DOCTYPE dataset SYSTEM \"%s\">", dtd)
+	}
+
 	if formatted {
-		return []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<dataset>\n")
+		if doctype != "" {
+			doctype += "\n"
+		}
+		return []byte(fmt.Sprintf("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n%s<dataset>\n", doctype))
 	}
 
-	return []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?><dataset>")
+	return []byte(fmt.Sprintf("<?xml version=\"1.0\" encoding=\"UTF-8\"?>%s<dataset>", doctype))
+}
+
+func xmlDTD(tables []string, columns map[string][]reader.DBColumn) []byte {
+	sb := strings.Builder{}
+	if len(tables) == 0 {
+		sb.WriteString("<!ELEMENT dataset EMPTY>\n")
+		return []byte(sb.String())
+	}
+
+	elements := make([]string, len(tables))
+	for i, table := range tables {
+		elements[i] = fmt.Sprintf("    %s", table)
+	}
+	sb.WriteString(fmt.Sprintf("<!ELEMENT dataset (\n%s)*>\n", strings.Join(elements, " |\n")))
+
+	for _, table := range tables {
+		sb.WriteString(fmt.Sprintf("\n<!ELEMENT %s EMPTY>\n<!ATTLIST %s\n", table, table))
+		for _, column := range columns[table] {
+			use := "#REQUIRED"
+			if column.Nullable {
+				use = "#IMPLIED"
+			}
+			sb.WriteString(fmt.Sprintf("    %s CDATA %s\n", column.Name, use))
+		}
+		sb.WriteString(">\n")
+	}
+
+	return []byte(sb.String())
 }
 
 func xmlFileFooter() []byte {
 	return []byte("</dataset>")
 }
 
-func xmlFileBody(formatted bool, table string, rows [][]*reader.DBColumn) []byte {
+func xmlFileBody(formatted bool, nullToken, table string, rows [][]*reader.DBColumn) []byte {
 	if formatted {
-		return formattedXMLRecord(table, rows)
+		return formattedXMLRecord(nullToken, table, rows)
 	}
 
-	return unformattedXMLRecord(table, rows)
+	return unformattedXMLRecord(nullToken, table, rows)
 }
 
-func formattedXMLRecord(table string, rows [][]*reader.DBColumn) []byte {
+func formattedXMLRecord(nullToken, table string, rows [][]*reader.DBColumn) []byte {
 	sb := strings.Builder{}
 
 	for _, row := range rows {
 		sb.WriteString(fmt.Sprintf("  <%s", table))
 
 		for _, column := range row {
-			if column.Value != nil {
-				sb.WriteString(fmt.Sprintf("\n    %s=\"%v\"", column.Name, column.Value))
+			if value, exists := xmlAttributeValue(column, nullToken); exists {
+				sb.WriteString(fmt.Sprintf("\n    %s=\"%s\"", column.Name, value))
 			}
 		}
 		sb.WriteString("/>\n")
@@ -105,14 +185,14 @@ func formattedXMLRecord(table string, rows [][]*reader.DBColumn) []byte {
 	return []byte(sb.String())
 }
 
-func unformattedXMLRecord(table string, rows [][]*reader.DBColumn) []byte {
+func unformattedXMLRecord(nullToken, table string, rows [][]*reader.DBColumn) []byte {
 	sb := strings.Builder{}
 
 	for _, row := range rows {
 		sb.WriteString(fmt.Sprintf("<%s", table))
 		for _, column := range row {
-			if column.Value != nil {
-				sb.WriteString(fmt.Sprintf(" %s=\"%v\"", column.Name, column.Value))
+			if value, exists := xmlAttributeValue(column, nullToken); exists {
+				sb.WriteString(fmt.Sprintf(" %s=\"%s\"", column.Name, value))
 			}
 		}
 		sb.WriteString("/>")
@@ -120,3 +200,18 @@ func unformattedXMLRecord(table string, rows [][]*reader.DBColumn) []byte {
 
 	return []byte(sb.String())
 }
+
+func xmlAttributeValue(column *reader.DBColumn, nullToken string) (string, bool) {
+	if column.Value == nil {
+		return xmlEscape(nullToken), nullToken != ""
+	}
+
+	return xmlEscape(textValue(column)), true
+}
+
+func xmlEscape(value string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(value))
+
+	return buf.String()
+}
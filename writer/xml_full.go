@@ -0,0 +1,139 @@
+package writer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type XMLFullWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	TableOrder  []string
+	file        *outputFile
+	tables      []string
+	records     map[string][][][]*reader.DBColumn
+}
+
+func (w *XMLFullWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("XMLFull.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s-full.xml", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("XMLFull.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.tables = make([]string, 0)
+	w.records = make(map[string][][][]*reader.DBColumn)
+
+	return nil
+}
+
+func (w *XMLFullWriter) WriteFooter() error {
+	sb := strings.Builder{}
+	sb.Write(xmlFileHeader(w.Formatted, ""))
+
+	for _, table := range orderedTables(w.TableOrder, w.tables) {
+		for _, rows := range w.records[table] {
+			sb.WriteString(xmlFullTable(w.Formatted, table, rows))
+		}
+	}
+
+	sb.Write(xmlFileFooter())
+
+	content := []byte(sb.String())
+	_, err := w.file.Write(content)
+	if err != nil {
+		log.Printf("XMLFull.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *XMLFullWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	chunks, exists := w.records[table]
+	if !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	last := len(chunks) - 1
+	if last >= 0 && sameColumns(chunks[last][0], rows[0]) {
+		chunks[last] = append(chunks[last], rows...)
+	} else {
+		chunks = append(chunks, rows)
+	}
+	w.records[table] = chunks
+
+	return nil
+}
+
+func sameColumns(row1, row2 []*reader.DBColumn) bool {
+	if len(row1) != len(row2) {
+		return false
+	}
+
+	for i := range row1 {
+		if row1[i].Name != row2[i].Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+func xmlFullTable(formatted bool, table string, rows [][]*reader.DBColumn) string {
+	indent := func(level int) string { return xmlIndent(formatted, level) }
+
+	sb := strings.Builder{}
+	sb.WriteString(strings.TrimPrefix(indent(1), "\n"))
+	sb.WriteString(fmt.Sprintf("<table name=\"%s\">", xmlEscape(table)))
+
+	for _, column := range rows[0] {
+		sb.WriteString(fmt.Sprintf("%s<column>%s</column>", indent(2), xmlEscape(column.Name)))
+	}
+
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("%s<row>", indent(2)))
+		for _, column := range row {
+			if column.Value == nil {
+				sb.WriteString(fmt.Sprintf("%s<null/>", indent(3)))
+			} else {
+				sb.WriteString(fmt.Sprintf("%s<value>%s</value>", indent(3), xmlEscape(textValue(column))))
+			}
+		}
+		sb.WriteString(fmt.Sprintf("%s</row>", indent(2)))
+	}
+
+	sb.WriteString(fmt.Sprintf("%s</table>", indent(1)))
+	if formatted {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func xmlIndent(formatted bool, level int) string {
+	if !formatted {
+		return ""
+	}
+
+	return "\n" + strings.Repeat("  ", level)
+}
@@ -0,0 +1,151 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLFullWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.XMLFullWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestXMLFullWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.XMLFullWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestXMLFullWriteFooterFileWritingError(t *testing.T) {
+	w := writer.XMLFullWriter{
+		Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"),
+		Name:      "test-xml-full-writing-error",
+	}
+	require.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestXMLFullWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XMLFullWriter{Directory: dir, Name: "test-xml-full-empty"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-full.xml", w.Name)))
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><dataset></dataset>", string(bytes))
+}
+
+func TestXMLFullWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XMLFullWriter{Directory: dir, Name: "test-xml-full-unformatted"}
+
+	require.Nil(t, w.WriteHeader())
+	writeXMLFullTestData(t, &w)
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-full.xml", w.Name)))
+	require.Nil(t, err)
+
+	expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?><dataset>" +
+		"<table name=\"products\"><column>id</column><column>name</column><column>description</column>" +
+		"<row><value>1</value><value>shirt</value><null/></row>" +
+		"<row><value>2</value><value>pant &amp; &lt;belt&gt;</value><value>&#34;blue&#34;</value></row>" +
+		"<row><value>3</value><value>sock</value><null/></row>" +
+		"</table>" +
+		"<table name=\"products\"><column>id</column><row><value>4</value></row></table>" +
+		"<table name=\"categories\"><column>id</column><row><value>10</value></row></table>" +
+		"</dataset>"
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestXMLFullWriteBinary(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XMLFullWriter{Directory: dir, Name: "test-xml-full-binary"}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("documents", [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "content", Type: "BLOB", Value: []byte{1, 2, 3}},
+	}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-full.xml", w.Name)))
+	require.Nil(t, err)
+
+	assert.Contains(t, string(bytes), "<row><value>1</value><value>AQID</value></row>")
+}
+
+func TestXMLFullWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XMLFullWriter{
+		Formatted: true, Directory: dir, Name: "test-xml-full-formatted", TableOrder: []string{"categories"},
+	}
+
+	require.Nil(t, w.WriteHeader())
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{
+		{{Name: "id", Value: 1}, {Name: "description", Value: nil}},
+	}))
+	require.Nil(t, w.Write("categories", [][]*reader.DBColumn{{{Name: "id", Value: 10}}}))
+	require.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s-full.xml", w.Name)))
+	require.Nil(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<dataset>
+  <table name="categories">
+    <column>id</column>
+    <row>
+      <value>10</value>
+    </row>
+  </table>
+  <table name="products">
+    <column>id</column>
+    <column>description</column>
+    <row>
+      <value>1</value>
+      <null/>
+    </row>
+  </table>
+</dataset>`
+	assert.Equal(t, expected, string(bytes))
+}
+
+func writeXMLFullTestData(t *testing.T, w writer.FileWriter) {
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{
+		{{Name: "id", Value: 1}, {Name: "name", Value: "shirt"}, {Name: "description", Value: nil}},
+		{{Name: "id", Value: 2}, {Name: "name", Value: "pant & <belt>"}, {Name: "description", Value: "\"blue\""}},
+	}))
+	require.Nil(t, w.Write("categories", [][]*reader.DBColumn{{{Name: "id", Value: 10}}}))
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{
+		{{Name: "id", Value: 3}, {Name: "name", Value: "sock"}, {Name: "description", Value: nil}},
+	}))
+	require.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 4}}}))
+}
@@ -80,7 +80,8 @@ func TestXMLWriteUnformattedEmptyData(t *testing.T) {
 	assert.Nil(t, w.WriteFooter())
 
 	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.xml", w.Name)))
-	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><dataset></dataset>", string(bytes))
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<!DOCTYPE dataset SYSTEM \"test-write-unformatted.dtd\"><dataset></dataset>", string(bytes))
 }
 
 func TestXMLWriteUnformatted(t *testing.T) {
@@ -108,7 +109,8 @@ func TestXMLWriteUnformatted(t *testing.T) {
 	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.xml", w.Name)))
 	xml := string(bytes)
 
-	assert.Contains(t, xml, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><dataset><products ")
+	assert.Contains(t, xml, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<!DOCTYPE dataset SYSTEM \"test-write-unformatted.dtd\"><dataset><products ")
 	assert.Contains(t, xml, "id=\"1\"")
 	assert.Contains(t, xml, "name=\"shirt\"")
 	assert.Contains(t, xml, "description=\"black shirt\"")
@@ -141,10 +143,110 @@ func TestXMLWriteFormatted(t *testing.T) {
 	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.xml", w.Name)))
 	xml := string(bytes)
 
-	assert.Contains(t, xml, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<dataset>\n  <products\n")
+	assert.Contains(t, xml, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<!DOCTYPE dataset SYSTEM \"test-write-formatted.dtd\">\n<dataset>\n  <products\n")
 	assert.Contains(t, xml, "    id=\"1\"")
 	assert.Contains(t, xml, "    name=\"shirt\"")
 	assert.Contains(t, xml, "    description=\"black shirt\"")
 	assert.Contains(t, xml, "    price=\"14.5\"")
 	assert.Contains(t, xml, "/>\n</dataset>")
 }
+
+func TestXMLWriteFooterDTDWritingError(t *testing.T) {
+	w := writer.XMLWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "dtd-error"}
+	assert.Nil(t, w.WriteHeader())
+
+	patches := gomonkey.ApplyFunc(os.WriteFile, func(string, []byte, fs.FileMode) error {
+		return fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestXMLWriteDTD(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XMLWriter{Directory: dir, Name: "test-write-dtd", TableOrder: []string{"customers", "orders"}}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("orders", [][]*reader.DBColumn{{
+		{Name: "id", Value: 1}, {Name: "customer_id", Value: 34}, {Name: "notes", Nullable: true},
+	}}))
+	assert.Nil(t, w.Write("customers", [][]*reader.DBColumn{{{Name: "id", Value: 34}}}))
+	assert.Nil(t, w.Write("orders", [][]*reader.DBColumn{{
+		{Name: "id", Value: 2}, {Name: "total", Value: 10.5, Nullable: true},
+	}}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.dtd", w.Name)))
+	assert.Nil(t, err)
+
+	expected := `<!ELEMENT dataset (
+    customers |
+    orders)*>
+
+<!ELEMENT customers EMPTY>
+<!ATTLIST customers
+    id CDATA #REQUIRED
+>
+
+<!ELEMENT orders EMPTY>
+<!ATTLIST orders
+    id CDATA #REQUIRED
+    customer_id CDATA #REQUIRED
+    notes CDATA #IMPLIED
+    total CDATA #IMPLIED
+>
+`
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestXMLWriteDTDEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XMLWriter{Directory: dir, Name: "test-write-dtd-empty"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.dtd", w.Name)))
+	assert.Nil(t, err)
+	assert.Equal(t, "<!ELEMENT dataset EMPTY>\n", string(bytes))
+}
+
+func TestXMLWriteEscaping(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.XMLWriter{Directory: dir, Name: "test-write-escaping"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{
+		{Name: "name", Value: "Tom & \"Jerry\" <'s>"},
+		{Name: "description", Value: "line 1\nline 2\tend\r"},
+		{Name: "code", Value: "a\x00b"},
+	}}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.xml", w.Name)))
+	assert.Contains(t, string(bytes), "<products name=\"Tom &amp; &#34;Jerry&#34; &lt;&#39;s&gt;\""+
+		" description=\"line 1&#xA;line 2&#x9;end&#xD;\" code=\"a�b\"/>")
+}
+
+func TestXMLWriteNullToken(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	rows := [][]*reader.DBColumn{{{Name: "id", Value: 1}, {Name: "description", Value: nil}}}
+
+	w := writer.XMLWriter{Directory: dir, Name: "test-write-null-token", NullToken: "[NULL]"}
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", rows))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.xml", w.Name)))
+	assert.Contains(t, string(bytes), "<products id=\"1\" description=\"[NULL]\"/>")
+
+	w = writer.XMLWriter{Formatted: true, Directory: dir, Name: "test-write-null-token"}
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", rows))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ = os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.xml", w.Name)))
+	assert.Contains(t, string(bytes), "  <products\n    id=\"1\"/>\n")
+}
@@ -0,0 +1,165 @@
+package writer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aureliano/db-unit-extractor/reader"
+)
+
+type YAMLWriter struct {
+	Formatted   bool
+	Directory   string
+	Name        string
+	Compression string
+	file        *outputFile
+	tables      []string
+	records     map[string][]string
+}
+
+var (
+	yamlReservedRegExp = regexp.MustCompile(
+		`^(?i:y|n|yes|no|on|off|true|false|null|~|\.nan|[-+]?\.inf)$`)
+	yamlNumberRegExp = regexp.MustCompile(
+		`^[-+]?(\.?[0-9][0-9_,.:eE+-]*|0[xob][0-9a-fA-F_]+)$`)
+	yamlTimestampRegExp = regexp.MustCompile(`^\d{4}-\d{1,2}-\d{1,2}`)
+	yamlIndicatorRegExp = regexp.MustCompile("^[-?:,\\[\\]{}#&*!|>'\"%@`\\s]|\\s$|: | #|[\\x00-\\x1f\\x7f]")
+)
+
+func (w *YAMLWriter) WriteHeader() error {
+	err := os.MkdirAll(w.Directory, os.ModePerm)
+	if err != nil {
+		log.Printf("YAML.WriteHeader\nMake directory %s failed with `%s'\n", w.Directory, err.Error())
+		return err
+	}
+
+	path := filepath.Join(w.Directory, fmt.Sprintf("%s.yml", w.Name))
+	w.file, err = createFile(path, w.Compression)
+	if err != nil {
+		log.Printf("YAML.WriteHeader\nFile %s not created: `%s'\n", path, err.Error())
+		return err
+	}
+
+	w.tables = make([]string, 0)
+	w.records = make(map[string][]string)
+	content := yamlFileHeader()
+	_, err = w.file.Write(content)
+	if err != nil {
+		log.Printf("YAML.WriteHeader\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+	}
+
+	return err
+}
+
+func (w *YAMLWriter) WriteFooter() error {
+	content := yamlFileBody(w.tables, w.records)
+	_, err := w.file.Write(content)
+	if err != nil {
+		log.Printf("YAML.WriteFooter\nWriting to file failed: `%s'\nContent: %s\n", err.Error(), content)
+		_ = w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
+
+func (w *YAMLWriter) Write(table string, rows [][]*reader.DBColumn) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if _, exists := w.records[table]; !exists {
+		w.tables = append(w.tables, table)
+	}
+
+	for _, row := range rows {
+		w.records[table] = append(w.records[table], yamlRecord(w.Formatted, "  ", row, yamlValue))
+	}
+
+	return nil
+}
+
+func yamlFileHeader() []byte {
+	return []byte("---\n")
+}
+
+func yamlFileBody(tables []string, records map[string][]string) []byte {
+	sb := strings.Builder{}
+	for _, table := range tables {
+		sb.WriteString(fmt.Sprintf("%s:\n", yamlString(table)))
+		for _, record := range records[table] {
+			sb.WriteString(record)
+		}
+	}
+
+	return []byte(sb.String())
+}
+
+func yamlRecord(formatted bool, indent string, row []*reader.DBColumn,
+	value func(*reader.DBColumn) string) string {
+	if formatted {
+		return formattedYAMLRecord(indent, row, value)
+	}
+
+	return unformattedYAMLRecord(indent, row, value)
+}
+
+func formattedYAMLRecord(indent string, row []*reader.DBColumn, value func(*reader.DBColumn) string) string {
+	if len(row) == 0 {
+		return indent + "- {}\n"
+	}
+
+	sb := strings.Builder{}
+	for i, column := range row {
+		if i == 0 {
+			sb.WriteString(indent + "- ")
+		} else {
+			sb.WriteString(indent + "  ")
+		}
+
+		sb.WriteString(fmt.Sprintf("%s: %s\n", yamlString(column.Name), value(column)))
+	}
+
+	return sb.String()
+}
+
+func unformattedYAMLRecord(indent string, row []*reader.DBColumn, value func(*reader.DBColumn) string) string {
+	fields := make([]string, len(row))
+	for i, column := range row {
+		fields[i] = fmt.Sprintf("%s: %s", yamlString(column.Name), value(column))
+	}
+
+	return fmt.Sprintf("%s- {%s}\n", indent, strings.Join(fields, ", "))
+}
+
+func yamlValue(column *reader.DBColumn) string {
+	if column.Value == nil {
+		return "null"
+	}
+
+	if value, isBool := booleanValue(column); isBool {
+		return strconv.FormatBool(value)
+	}
+
+	if value, isNumber := numericValue(column); isNumber {
+		return value
+	}
+
+	return yamlString(textValue(column))
+}
+
+func yamlString(value string) string {
+	if value == "" || yamlReservedRegExp.MatchString(value) || yamlNumberRegExp.MatchString(value) ||
+		yamlTimestampRegExp.MatchString(value) || yamlIndicatorRegExp.MatchString(value) ||
+		strings.ContainsAny(value, "{}[],") {
+		return jsonString(value)
+	}
+
+	return value
+}
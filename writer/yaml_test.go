@@ -0,0 +1,184 @@
+package writer_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/aureliano/db-unit-extractor/reader"
+	"github.com/aureliano/db-unit-extractor/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestYAMLWriteHeaderMkdirAllError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.MkdirAll, func(string, fs.FileMode) error {
+		return fmt.Errorf("mkdir error")
+	})
+	defer patches.Reset()
+
+	w := writer.YAMLWriter{}
+
+	assert.Equal(t, "mkdir error", w.WriteHeader().Error())
+}
+
+func TestYAMLWriteHeaderFileCreationError(t *testing.T) {
+	patches := gomonkey.ApplyFunc(os.OpenFile, func(string, int, fs.FileMode) (*os.File, error) {
+		return nil, fmt.Errorf("file creation error")
+	})
+	defer patches.Reset()
+
+	w := writer.YAMLWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file creation error", w.WriteHeader().Error())
+}
+
+func TestYAMLWriteHeaderFileWritingError(t *testing.T) {
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	w := writer.YAMLWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer")}
+	assert.Equal(t, "file writing error", w.WriteHeader().Error())
+}
+
+func TestYAMLWriteFooterFileWritingError(t *testing.T) {
+	w := writer.YAMLWriter{Directory: filepath.Join(os.TempDir(), "db-unit-extractor", "writer"), Name: "test-error"}
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Value: 1}}}))
+
+	patches := gomonkey.ApplyMethodFunc(&os.File{}, "Write", func([]byte) (int, error) {
+		return 0, fmt.Errorf("file writing error")
+	})
+	defer patches.Reset()
+
+	assert.Equal(t, "file writing error", w.WriteFooter().Error())
+}
+
+func TestYAMLWriteEmptyData(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.YAMLWriter{
+		Formatted: false,
+		Directory: dir,
+		Name:      "test-write-empty",
+	}
+
+	assert.Nil(t, w.WriteHeader())
+
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.yml", w.Name)))
+	assert.Equal(t, "---\n", string(bytes))
+}
+
+func TestYAMLWriteUnformatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.YAMLWriter{
+		Formatted: false,
+		Directory: dir,
+		Name:      "test-write-unformatted",
+	}
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "name", Type: "VARCHAR", Value: "shirt"},
+		{Name: "description", Type: "VARCHAR"},
+		{Name: "price", Type: "NUMBER", Value: 14.5},
+	}}
+
+	assert.Nil(t, w.Write("products", rows))
+	assert.Nil(t, w.Write("categories", [][]*reader.DBColumn{{{Name: "code", Type: "CHAR", Value: "007"}}}))
+	assert.Nil(t, w.Write("products", [][]*reader.DBColumn{{{Name: "id", Type: "INTEGER", Value: 2}}}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.yml", w.Name)))
+	expected := "---\nproducts:\n  - {id: 1, name: shirt, description: null, price: 14.5}\n  - {id: 2}\n" +
+		"categories:\n  - {code: \"007\"}\n"
+
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestYAMLWriteBinary(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.YAMLWriter{Directory: dir, Name: "test-write-binary"}
+
+	assert.Nil(t, w.WriteHeader())
+	assert.Nil(t, w.Write("documents", [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "content", Type: "BLOB", Value: []byte{1, 2, 3}},
+		{Name: "thumbnail", Type: "BLOB", Value: []byte{0xfb, 0xff}},
+	}}))
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.yml", w.Name)))
+	assert.Equal(t, "---\ndocuments:\n  - {id: 1, content: AQID, thumbnail: +/8=}\n", string(bytes))
+}
+
+func TestYAMLWriteFormatted(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.YAMLWriter{
+		Formatted: true,
+		Directory: dir,
+		Name:      "test-write-formatted",
+	}
+
+	assert.Nil(t, w.WriteHeader())
+
+	rows := [][]*reader.DBColumn{{
+		{Name: "id", Type: "INTEGER", Value: 1},
+		{Name: "name", Type: "VARCHAR", Value: "shirt"},
+		{Name: "active", Type: "BOOLEAN", Value: true},
+	}}
+
+	assert.Nil(t, w.Write("products", rows))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.yml", w.Name)))
+	expected := "---\nproducts:\n  - id: 1\n    name: shirt\n    active: true\n"
+
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestYAMLWriteQuotedStrings(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "db-unit-extractor", "writer")
+	w := writer.YAMLWriter{
+		Formatted: true,
+		Directory: dir,
+		Name:      "test-write-quoted",
+	}
+
+	assert.Nil(t, w.WriteHeader())
+
+	values := []string{
+		"yes", "No", "on", "null", "~", "0012", "1.5", "1e3", "0x1F", "12:30", "2023-06-09",
+		"2023-06-09T14:31:16.478 +0000", "", " leading", "trailing ", "key: value", "text #comment",
+		"- item", "[list]", "{map}", "a, b", "*alias", "line\nbreak", "quote\"d", "plain text",
+	}
+	row := make([]*reader.DBColumn, len(values))
+	for i, v := range values {
+		row[i] = &reader.DBColumn{Name: fmt.Sprintf("c%d", i), Type: "VARCHAR", Value: v}
+	}
+
+	assert.Nil(t, w.Write("texts", [][]*reader.DBColumn{row}))
+
+	assert.Nil(t, w.WriteFooter())
+
+	bytes, _ := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%s.yml", w.Name)))
+	dataset := make(map[string][]map[string]interface{})
+	require.Nil(t, yaml.Unmarshal(bytes, &dataset))
+
+	for i, v := range values {
+		assert.Equal(t, v, dataset["texts"][0][fmt.Sprintf("c%d", i)])
+	}
+	assert.Contains(t, string(bytes), "c24: plain text\n")
+}